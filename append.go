@@ -15,12 +15,8 @@ func Append(b []byte, items ...any) []byte {
 // the byte slice, and returns the updated slice.
 func Appendf(b []byte, format string, items ...any) []byte {
 	// Fast path for no arguments - just return the input as-is
-	switch {
-	case len(items) == 0:
-		return b
-	case len(items) == 1 && items[0] == nil:
-		b = append(b, nilAngleString...)
-		return b
+	if len(items) == 0 {
+		return append(b, format...)
 	}
 	p := newPrinter()
 	p.printf(format, items)
@@ -30,6 +26,17 @@ func Appendf(b []byte, format string, items ...any) []byte {
 	return b
 }
 
+// Appendln formats using the default formats for its operands, appends the result
+// to the byte slice, and returns the updated slice. Spaces are always added
+// between operands and a newline is appended.
+func Appendln(b []byte, items ...any) []byte {
+	p := newPrinter()
+	p.println(items)
+	b = append(b, p.buf...)
+	p.free()
+	return b
+}
+
 // doappend formats the arguments using their default formats (%v verb)
 // and places them into p.buf with appropriate spacing.
 func (p *printer) doappend(items []any) {
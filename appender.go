@@ -0,0 +1,173 @@
+package xprint
+
+import (
+	"encoding"
+	"sync"
+
+	reflect "github.com/goccy/go-reflect"
+)
+
+// Appender is implemented by types that format themselves by appending to a
+// byte slice, avoiding the string a String method allocates per call.
+// AppendFormat is preferred over Error and String methods for every verb
+// except %T, %p and %#v, for top-level arguments and for values nested in
+// slices, maps, structs and pointers. Width and the '-' flag are applied to
+// the appended bytes.
+type Appender interface {
+	AppendFormat(dst []byte, verb rune) []byte
+}
+
+// VerbAppender is an Appender that also tells %v, %+v and %#v apart, as
+// generated by cmd/xprintgen. It is preferred over Appender and used for %#v
+// too. Pointers to struct types are printed with a leading '&' as fmt does.
+type VerbAppender interface {
+	AppendVerb(dst []byte, verb rune, plus, sharp bool) []byte
+}
+
+var (
+	appenderType     = reflect.TypeOf((*Appender)(nil)).Elem()
+	verbAppenderType = reflect.TypeOf((*VerbAppender)(nil)).Elem()
+	textAppenderType = reflect.TypeOf((*encoding.TextAppender)(nil)).Elem()
+
+	// appenderTypes caches whether a type implements Appender or, for
+	// UseTextMarshaler, encoding.TextAppender. Nested values read the kind
+	// from their type's plan instead.
+	appenderTypes sync.Map // map[reflect.Type]appenderKind
+)
+
+type appenderKind uint8
+
+const (
+	appenderNone appenderKind = iota
+	appenderSelf
+	appenderVerb
+	appenderText
+)
+
+// printAppender formats arg through its AppendVerb or AppendFormat method.
+func (p *printer) printAppender(arg any, verb rune) bool {
+	if verb == 'T' || verb == 'p' {
+		return false
+	}
+	if a, ok := arg.(VerbAppender); ok {
+		return p.callVerbAppender(a, verb)
+	}
+	a, ok := arg.(Appender)
+	if !ok || p.fmt.sharpV {
+		return false
+	}
+	p.callAppender(a, nil, verb)
+	return true
+}
+
+// callVerbAppender runs AppendVerb, printing pointers to structs like fmt.
+// Nil pointers are left to the regular pointer formatting for verbs other
+// than %v.
+func (p *printer) callVerbAppender(a VerbAppender, verb rune) bool {
+	if v := reflect.ValueOf(a); v.Kind() == reflect.Ptr {
+		switch {
+		case v.IsNil() && verb != 'v':
+			return false
+		case v.IsNil() && p.fmt.sharpV:
+			p.buf.writeString("(" + v.Type().String() + ")(nil)")
+			return true
+		case v.IsNil():
+			p.buf.writeString(nilAngleString)
+			return true
+		case v.Elem().Kind() == reflect.Struct:
+			p.buf.writeByte('&')
+		}
+	}
+	p.callAppender(nil, a, verb)
+	return true
+}
+
+// callAppender runs the AppendVerb method of va, or the AppendFormat method
+// of a if va is nil, and pads the result.
+func (p *printer) callAppender(a Appender, va VerbAppender, verb rune) {
+	start := len(p.buf)
+	defer func() {
+		if r := recover(); r != nil {
+			p.buf = p.buf[:start]
+			var recv any = a
+			if va != nil {
+				recv = va
+			}
+			if v := reflect.ValueOf(recv); v.Kind() == reflect.Ptr && v.IsNil() {
+				p.buf.writeString(nilAngleString)
+				return
+			}
+			p.buf.writeString(percentBangString)
+			p.buf.writeRune(verb)
+			p.buf.writeString(panicString)
+			p.buf.writeString("AppendFormat method: ")
+			p.buf.writeString(Printf("%v", r))
+			p.buf.writeByte(')')
+		}
+	}()
+	if va != nil {
+		p.buf = va.AppendVerb(p.buf, verb, p.fmt.plusV, p.fmt.sharpV)
+	} else {
+		p.buf = a.AppendFormat(p.buf, verb)
+	}
+	p.padFrom(start)
+}
+
+// padFrom pads the output written since start to the directive's width.
+func (p *printer) padFrom(start int) {
+	if !p.fmt.widPresent {
+		return
+	}
+	pad := p.fmt.wid - bytesWidth(p.buf[start:])
+	if p.fmt.minus {
+		p.writePadding(pad, ' ')
+	} else {
+		p.padBefore(start, pad, ' ')
+	}
+}
+
+// printAppenderValue is printAppender for values reached by reflection,
+// whose type has the given appender kind. It also covers
+// encoding.TextAppender when UseTextMarshaler is enabled.
+func (p *printer) printAppenderValue(v reflect.Value, kind appenderKind, verb rune) bool {
+	if kind == appenderNone || !v.CanInterface() {
+		return false
+	}
+	if kind == appenderVerb {
+		return p.callVerbAppender(v.Interface().(VerbAppender), verb) //nolint:forcetypeassert // checked by appenderKindOf
+	}
+	if p.fmt.sharpV {
+		return false
+	}
+	switch kind {
+	case appenderSelf:
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return false
+		}
+		p.callAppender(v.Interface().(Appender), nil, verb) //nolint:forcetypeassert // checked by appenderKindOf
+		return true
+	case appenderText:
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return false
+		}
+		return p.printTextMarshaler(v.Interface())
+	}
+	return false
+}
+
+func appenderKindOf(t reflect.Type) appenderKind {
+	if k, ok := appenderTypes.Load(t); ok {
+		return k.(appenderKind) //nolint:forcetypeassert // cache only holds appenderKind
+	}
+	k := appenderNone
+	switch {
+	case t.Implements(verbAppenderType):
+		k = appenderVerb
+	case t.Implements(appenderType):
+		k = appenderSelf
+	case t.Implements(textAppenderType):
+		k = appenderText
+	}
+	appenderTypes.Store(t, k)
+	return k
+}
@@ -0,0 +1,65 @@
+package xprint_test
+
+import (
+	"strconv"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+type orderID uint32
+
+func (id orderID) AppendFormat(dst []byte, verb rune) []byte {
+	dst = append(dst, "ord-"...)
+	if verb == 'x' {
+		return strconv.AppendUint(dst, uint64(id), 16)
+	}
+	return strconv.AppendUint(dst, uint64(id), 10)
+}
+
+// String must lose against AppendFormat
+func (id orderID) String() string { return "string-called" }
+
+type money struct{ cents int64 }
+
+func (m *money) AppendFormat(dst []byte, _ rune) []byte {
+	dst = strconv.AppendInt(dst, m.cents/100, 10)
+	dst = append(dst, '.')
+	if c := m.cents % 100; c < 10 {
+		dst = append(dst, '0')
+	}
+	return strconv.AppendInt(dst, m.cents%100, 10)
+}
+
+func TestAppender(t *testing.T) {
+	testCases := []struct {
+		format   string
+		arg      any
+		expected string
+	}{
+		{"%v", orderID(42), "ord-42"},
+		{"%s", orderID(42), "ord-42"},
+		{"%x", orderID(255), "ord-ff"},
+		{"[%8v]", orderID(7), "[   ord-7]"},
+		{"[%-8v]", orderID(7), "[ord-7   ]"},
+		{"%v", []orderID{1, 2}, "[ord-1 ord-2]"},
+		{"%v", struct{ ID orderID }{3}, "{ord-3}"},
+		{"%+v", map[string]orderID{"a": 4}, "map[a:ord-4]"},
+		{"%v", &money{1205}, "12.05"},
+		{"%v", (*money)(nil), "<nil>"},
+		{"%T", orderID(1), "xprint_test.orderID"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if o := xprint.Printf(tc.format, tc.arg); o != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, o)
+			}
+		})
+	}
+
+	id := orderID(42)
+	if n := testing.AllocsPerRun(100, func() { _ = xprint.Appendf(make([]byte, 0, 32), "%v", id) }); n > 1 {
+		t.Errorf("Expected at most 1 allocation, got %v", n)
+	}
+}
@@ -0,0 +1,82 @@
+package benchmark_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	xprint "gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// BenchmarkMixedSizes formats a stream of messages where every tenth one
+// carries a large payload, the workload a single pooled-buffer cutoff handles
+// worst.
+func BenchmarkMixedSizes(b *testing.B) {
+	for _, size := range []int{16 << 10, 256 << 10, 768 << 10} {
+		payload := strings.Repeat("p", size)
+		name := fmt.Sprintf("%dKB", size>>10)
+
+		b.Run(name+"/fmt-Fprintf", func(b *testing.B) {
+			b.ReportAllocs()
+			i := 0
+			for b.Loop() {
+				if i++; i%10 == 0 {
+					_, _ = fmt.Fprintf(io.Discard, "request %d: %s", i, payload)
+				} else {
+					_, _ = fmt.Fprintf(io.Discard, "request %d: %s", i, "ok")
+				}
+			}
+		})
+		b.Run(name+"/xprint-Fprintf", func(b *testing.B) {
+			b.ReportAllocs()
+			i := 0
+			for b.Loop() {
+				if i++; i%10 == 0 {
+					_, _ = xprint.Fprintf(io.Discard, "request %d: %s", i, payload)
+				} else {
+					_, _ = xprint.Fprintf(io.Discard, "request %d: %s", i, "ok")
+				}
+			}
+		})
+		// A zero limit keeps only the buffers a printer holds itself, the
+		// single 64KB cutoff used before size classes
+		b.Run(name+"/xprint-Fprintf-64KB-cutoff", func(b *testing.B) {
+			xprint.SetBufferPoolLimit(0)
+			defer xprint.SetBufferPoolLimit(1 << 20)
+			b.ReportAllocs()
+			i := 0
+			for b.Loop() {
+				if i++; i%10 == 0 {
+					_, _ = xprint.Fprintf(io.Discard, "request %d: %s", i, payload)
+				} else {
+					_, _ = xprint.Fprintf(io.Discard, "request %d: %s", i, "ok")
+				}
+			}
+		})
+		b.Run(name+"/xprint-Sprintf", func(b *testing.B) {
+			b.ReportAllocs()
+			i := 0
+			for b.Loop() {
+				if i++; i%10 == 0 {
+					_ = xprint.Sprintf("request %d: %s", i, payload)
+				} else {
+					_ = xprint.Sprintf("request %d: %s", i, "ok")
+				}
+			}
+		})
+		b.Run(name+"/xprint-parallel", func(b *testing.B) {
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if i++; i%10 == 0 {
+						_, _ = xprint.Fprintf(io.Discard, "request %d: %s", i, payload)
+					} else {
+						_, _ = xprint.Fprintf(io.Discard, "request %d: %s", i, "ok")
+					}
+				}
+			})
+		})
+	}
+}
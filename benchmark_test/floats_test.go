@@ -0,0 +1,43 @@
+package benchmark_test
+
+import (
+	"fmt"
+	"testing"
+
+	xprint "gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// BenchmarkFloats covers float and complex formatting across verbs and flags.
+func BenchmarkFloats(b *testing.B) {
+	cases := []struct {
+		name   string
+		format string
+		arg    any
+	}{
+		{"float64/v", "%v", 3.14159},
+		{"float64/f", "%f", 3.14159},
+		{"float64/.2f", "%.2f", -1234.5678},
+		{"float64/+9.3f", "%+9.3f", 2.5},
+		{"float64/08.3f", "%08.3f", -2.5},
+		{"float64/e", "%e", 6.02214076e23},
+		{"float32/g", "%g", float32(0.1)},
+		{"float64/#g", "%#g", 1.5},
+		{"complex128/v", "%v", complex(1.5, -2)},
+	}
+
+	for _, c := range cases {
+		buf := make([]byte, 0, 128)
+		b.Run(c.name+"/fmt", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				buf = fmt.Appendf(buf[:0], c.format, c.arg)
+			}
+		})
+		b.Run(c.name+"/xprint", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				buf = xprint.Appendf(buf[:0], c.format, c.arg)
+			}
+		})
+	}
+}
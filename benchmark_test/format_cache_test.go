@@ -0,0 +1,68 @@
+package benchmark_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	xprint "gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// BenchmarkFormatCache compares constant formats parsed on every call with
+// the same formats served from the format cache.
+func BenchmarkFormatCache(b *testing.B) {
+	errBase := errors.New("connection refused")
+	cases := []struct {
+		name   string
+		format string
+		args   []any
+	}{
+		{"directives", "%-8s|%6d|%08.3f|%x|%+v", []any{"name", 42, 3.14159, 255, true}},
+		{"log-line", "user %s (%d) logged in from %s at %s: %v", []any{"alice", 1001, "10.0.0.1", "12:00", true}},
+		{"strings", "%s=%s;", []any{"key", "value"}},
+	}
+
+	for _, c := range cases {
+		b.Run(c.name+"/fmt", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = fmt.Sprintf(c.format, c.args...)
+			}
+		})
+		b.Run(c.name+"/xprint", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = xprint.Sprintf(c.format, c.args...)
+			}
+		})
+		b.Run(c.name+"/xprint-cached", func(b *testing.B) {
+			xprint.CacheFormats(true)
+			defer xprint.CacheFormats(false)
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = xprint.Sprintf(c.format, c.args...)
+			}
+		})
+	}
+
+	b.Run("errorf/fmt", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = fmt.Errorf("dial %s:%d: %w", "db", 5432, errBase)
+		}
+	})
+	b.Run("errorf/xprint", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = xprint.Errorf("dial %s:%d: %w", "db", 5432, errBase)
+		}
+	})
+	b.Run("errorf/xprint-cached", func(b *testing.B) {
+		xprint.CacheFormats(true)
+		defer xprint.CacheFormats(false)
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = xprint.Errorf("dial %s:%d: %w", "db", 5432, errBase)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package benchmark_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	xprint "gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// BenchmarkFormatScan measures format strings dominated by literal text and
+// by plain %s directives.
+func BenchmarkFormatScan(b *testing.B) {
+	literal := strings.Repeat("the quick brown fox jumps over the lazy dog. ", 40)
+	cases := []struct {
+		name   string
+		format string
+		args   []any
+	}{
+		{"strings", "%s=%s;", []any{"key", "value"}},
+		{"string-literal", literal + "%s" + literal, []any{"value"}},
+		{"int-literal", literal + "%d" + literal, []any{42}},
+		{"mixed", "user %s (%d) logged in from %s at %s: %v", []any{"alice", 1001, "10.0.0.1", "12:00", true}},
+		{"many-percents", "100%% of %s is 100%% of %s", []any{"a", "b"}},
+	}
+
+	for _, c := range cases {
+		b.Run(c.name+"/fmt", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = fmt.Sprintf(c.format, c.args...)
+			}
+		})
+		b.Run(c.name+"/xprint", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = xprint.Sprintf(c.format, c.args...)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package benchmark_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	xprint "gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// BenchmarkIntegers covers the integer core across types, bases and flags.
+func BenchmarkIntegers(b *testing.B) {
+	cases := []struct {
+		name   string
+		format string
+		arg    any
+	}{
+		{"small/d", "%d", 42},
+		{"int/d", "%d", 1234567890},
+		{"int64/d", "%d", int64(-9223372036854775807)},
+		{"uint8/v", "%v", uint8(200)},
+		{"uint64/x", "%x", uint64(0xdeadbeefcafe)},
+		{"int/#x", "%#x", 0xbeef},
+		{"int/08d", "%08d", -4242},
+		{"int32/+.6d", "%+.6d", int32(77)},
+		{"int/b", "%b", 1023},
+		{"int/o", "%o", 511},
+	}
+
+	for _, c := range cases {
+		buf := make([]byte, 0, 128)
+		b.Run(c.name+"/fmt", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				buf = fmt.Appendf(buf[:0], c.format, c.arg)
+			}
+		})
+		b.Run(c.name+"/xprint", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				buf = xprint.Appendf(buf[:0], c.format, c.arg)
+			}
+		})
+	}
+}
+
+// smallInts is the lookup table the integer writers consulted for base-10
+// values from -99 to 99 before the generic core replaced them.
+var smallInts = func() map[int]string {
+	m := make(map[int]string, 199)
+	for i := -99; i <= 99; i++ {
+		m[i] = strconv.Itoa(i)
+	}
+	return m
+}()
+
+// BenchmarkSmallIntTable compares the removed small-integer table with
+// converting the digits of every value. strconv.AppendInt converts two digits
+// at a time like the integer core.
+func BenchmarkSmallIntTable(b *testing.B) {
+	values := []int{0, 7, 42, -13, 99, 100, 65535}
+	buf := make([]byte, 0, 64)
+	b.Run("table", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			buf = buf[:0]
+			for _, v := range values {
+				if s, ok := smallInts[v]; ok {
+					buf = append(buf, s...)
+				} else {
+					buf = strconv.AppendInt(buf, int64(v), 10)
+				}
+			}
+		}
+	})
+	b.Run("digits", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			buf = buf[:0]
+			for _, v := range values {
+				buf = strconv.AppendInt(buf, int64(v), 10)
+			}
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package benchmark_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	xprint "gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// BenchmarkLease compares writing a string result with writing a leased
+// buffer, for small outputs and for outputs too large to be pooled.
+func BenchmarkLease(b *testing.B) {
+	large := strings.Repeat("0123456789abcdef", 64<<10) // 1MB
+	cases := []struct {
+		name   string
+		format string
+		args   []any
+	}{
+		{"small", "%s=%d (%s)", []any{"key", 12345, "value"}},
+		{"1MB", "payload %s (%d bytes)", []any{large, len(large)}},
+	}
+
+	for _, c := range cases {
+		b.Run(c.name+"/fmt-Sprintf", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = io.WriteString(io.Discard, fmt.Sprintf(c.format, c.args...))
+			}
+		})
+		b.Run(c.name+"/xprint-Sprintf", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = io.WriteString(io.Discard, xprint.Sprintf(c.format, c.args...))
+			}
+		})
+		b.Run(c.name+"/xprint-Leasef", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				l := xprint.Leasef(c.format, c.args...)
+				_, _ = io.Discard.Write(l.Bytes())
+				l.Release()
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package benchmark_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	xprint "gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// BenchmarkNestedValues measures values reached by reflection inside
+// slices, maps and structs, where every element is checked for methods such
+// as AppendFormat.
+func BenchmarkNestedValues(b *testing.B) {
+	words := make([]any, 100)
+	for i := range words {
+		words[i] = "word" + strconv.Itoa(i)
+	}
+	names := []string{"alice", "bob", "carol", "dave"}
+	hosts := []string{"db1", "db2", "cache", "web1", "web2"}
+	tags := []any{"prod", "eu-west", "v2"}
+	type row struct {
+		ID    int
+		Name  string
+		Tags  []string
+		Score float64
+	}
+	rows := []row{{1, "a", []string{"x"}, 1.5}, {2, "b", []string{"y", "z"}, 2.5}}
+	cases := []struct {
+		name   string
+		format string
+		args   []any
+	}{
+		{"any-strings", "%v", []any{words}},
+		{"three-slices", "%s %s %s", []any{names, hosts, tags}},
+		{"map", "%v", []any{map[string]int{"a": 1}}},
+		{"structs", "%+v", []any{rows}},
+	}
+
+	for _, c := range cases {
+		b.Run(c.name+"/fmt", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = fmt.Sprintf(c.format, c.args...)
+			}
+		})
+		b.Run(c.name+"/xprint", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = xprint.Sprintf(c.format, c.args...)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package benchmark_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	xprint "gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// BenchmarkOutputSize measures outputs from a few bytes to several megabytes,
+// where growing the buffer while formatting would copy the output repeatedly.
+func BenchmarkOutputSize(b *testing.B) {
+	json := strings.Repeat(`{"id":12345,"name":"example","tags":["a","b"]},`, 110_000) // about 5MB
+	small := strings.Repeat("x", 1<<20)
+	cases := []struct {
+		name   string
+		format string
+		args   []any
+	}{
+		{"small", "%s=%d (%s)", []any{"key", 12345, "value"}},
+		{"64KB", "%s|%d|%s", []any{json[:32<<10], 42, []byte(json[:32<<10])}},
+		{"5MB+1MB", "%s \n\nHello world %s", []any{json, small}},
+		{"5MB-bytes", "payload: %s (%d bytes)", []any{[]byte(json), len(json)}},
+	}
+
+	for _, c := range cases {
+		b.Run(c.name+"/fmt", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = fmt.Sprintf(c.format, c.args...)
+			}
+		})
+		b.Run(c.name+"/xprint", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = xprint.Sprintf(c.format, c.args...)
+			}
+		})
+		b.Run(c.name+"/xprint-Fprintf", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = xprint.Fprintf(io.Discard, c.format, c.args...)
+			}
+		})
+	}
+
+	b.Run("5MB-Appendf/xprint", func(b *testing.B) {
+		var buf bytes.Buffer
+		b.ReportAllocs()
+		for b.Loop() {
+			buf.Write(xprint.Appendf(nil, "%s %d", json, 1))
+			buf.Reset()
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package benchmark_test
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	xprint "gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// BenchmarkWellKnownTypes compares the allocation-free paths for time and
+// netip values against fmt, which goes through their String methods.
+func BenchmarkWellKnownTypes(b *testing.B) {
+	cases := []struct {
+		name string
+		arg  any
+	}{
+		{"time.Time", time.Date(2025, 3, 7, 12, 30, 45, 123456789, time.UTC)},
+		{"time.Duration", 90*time.Minute + 1500*time.Millisecond},
+		{"netip.Addr", netip.MustParseAddr("2001:db8::1")},
+		{"netip.AddrPort", netip.MustParseAddrPort("192.168.0.1:8080")},
+	}
+
+	for _, c := range cases {
+		buf := make([]byte, 0, 128)
+		b.Run(c.name+"/fmt", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				buf = fmt.Appendf(buf[:0], "at %v", c.arg)
+			}
+		})
+		b.Run(c.name+"/xprint", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				buf = xprint.Appendf(buf[:0], "at %v", c.arg)
+			}
+		})
+	}
+}
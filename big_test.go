@@ -0,0 +1,66 @@
+package xprint_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func TestBigNumbers(t *testing.T) {
+	bi, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	bf, _ := new(big.Float).SetPrec(200).SetString("31415926535897932384626.43383279502884197")
+
+	formats := []string{
+		"%v", "%d", "%x", "%X", "%#x", "%o", "%O", "%b", "%40d", "%-40d|", "%+d", "%045d",
+		"%e", "%.3e", "%f", "%.10f", "%g", "%.20g", "%30.2f", "%-30.2f|", "%+.1e",
+	}
+	for _, format := range formats {
+		for _, arg := range []any{bi, bf} {
+			o := xprint.Printf(format, arg)
+			fo := fmt.Sprintf(format, arg)
+			if o != fo {
+				t.Errorf("%s %T: Expected %s, got %s", format, arg, fo, o)
+			}
+		}
+	}
+}
+
+func TestBigNested(t *testing.T) {
+	v := struct {
+		N *big.Int
+		F *big.Float
+	}{big.NewInt(255), big.NewFloat(1.5)}
+	for _, format := range []string{"%v", "%+v", "%x"} {
+		o := xprint.Printf(format, v)
+		fo := fmt.Sprintf(format, v)
+		if o != fo {
+			t.Errorf("%s: Expected %s, got %s", format, fo, o)
+		}
+	}
+}
+
+func TestBigRat(t *testing.T) {
+	third := big.NewRat(1, 3)
+	testCases := []struct {
+		format   string
+		arg      *big.Rat
+		expected string
+	}{
+		{"%v", third, "1/3"},
+		{"%8s|", third, "     1/3|"},
+		{"%f", third, "0.333333"},
+		{"%.2f", big.NewRat(-5, 2), "-2.50"},
+		{"%+08.3f", third, "+000.333"},
+		{"%.3e", third, "3.333e-01"},
+		{"%d", big.NewRat(84, 2), "42"},
+		{"%x", big.NewRat(255, 1), "ff"},
+		{"%d", third, "%!d%!(BADVERB)"},
+	}
+	for _, tc := range testCases {
+		if o := xprint.Printf(tc.format, tc.arg); o != tc.expected {
+			t.Errorf("%s: Expected %s, got %s", tc.format, tc.expected, o)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package xprint
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// braceCache holds compiled brace formats, bounded like namedCache.
+var braceCache templateCache[[]directive]
+
+// Format formats according to a Python or Rust style format string and
+// returns the resulting string. Replacement fields are written
+//
+//	{[index][:[[fill]align][sign][#][0][width][,][.precision][verb]]}
+//
+// where index selects the argument (fields without one take the next
+// argument), align is '<', '>' or '^' for left, right or centered output
+// padded with fill (default space), sign is '+', '-' or ' ', ',' groups
+// digits and verb is any Printf verb. Without an explicit alignment numbers
+// are right-aligned and everything else left-aligned, as in Python.
+// Use {{ and }} for literal braces.
+func Format(format string, args ...any) string {
+	p := newPrinter()
+	p.printBraces(compileBraces(format), args)
+	return p.takeString()
+}
+
+// AppendFormat is like Format but appends the result to b.
+func AppendFormat(b []byte, format string, args ...any) []byte {
+	p := newPrinter()
+	p.printBraces(compileBraces(format), args)
+	b = append(b, p.buf...)
+	p.free()
+	return b
+}
+
+func compileBraces(format string) []directive {
+	return braceCache.get(format, parseBraces)
+}
+
+// parseBraces compiles format into directives. Malformed fields are kept as
+// literal text.
+func parseBraces(format string) []directive {
+	var directives []directive
+	var lit []byte
+	end := len(format)
+	for i := 0; i < end; {
+		c := format[i]
+		if (c == '{' || c == '}') && i+1 < end && format[i+1] == c {
+			lit = append(lit, c)
+			i += 2
+			continue
+		}
+		if c == '{' {
+			if closing := strings.IndexByte(format[i:], '}'); closing > 0 {
+				if d, ok := parseBraceField(format[i+1 : i+closing]); ok {
+					d.lit = string(lit)
+					lit = lit[:0]
+					directives = append(directives, d)
+					i += closing + 1
+					continue
+				}
+			}
+		}
+		lit = append(lit, c)
+		i++
+	}
+	return append(directives, directive{lit: string(lit)})
+}
+
+// parseBraceField parses the contents of a replacement field.
+func parseBraceField(field string) (directive, bool) {
+	d := directive{index: -1, verb: 'v', fill: ' ', hasArg: true}
+	index, spec, _ := strings.Cut(field, ":")
+	if index != "" {
+		n, ok, i := parsenum(index, 0, len(index))
+		if !ok || i != len(index) {
+			return d, false
+		}
+		d.index = n
+	}
+	if spec == "" {
+		return d, true
+	}
+
+	// [[fill]align]
+	if r, size := utf8.DecodeRuneInString(spec); size < len(spec) && isAlign(spec[size]) {
+		d.fill, d.align = r, spec[size]
+		spec = spec[size+1:]
+	} else if isAlign(spec[0]) {
+		d.align = spec[0]
+		spec = spec[1:]
+	}
+
+	f := &d.flags
+	i, end := 0, len(spec)
+	if i < end {
+		switch spec[i] {
+		case '+':
+			f.plus = true
+			i++
+		case ' ':
+			f.space = true
+			i++
+		case '-':
+			i++
+		}
+	}
+	if i < end && spec[i] == '#' {
+		f.sharp = true
+		i++
+	}
+	if i < end && spec[i] == '0' {
+		f.zero = true
+		i++
+	}
+	f.wid, f.widPresent, i = parsenum(spec, i, end)
+	if i < end && spec[i] == ',' {
+		f.group = true
+		i++
+	}
+	if i < end && spec[i] == '.' {
+		f.prec, _, i = parsenum(spec, i+1, end)
+		f.precPresent = true
+	}
+	if i < end {
+		d.verb = rune(spec[i])
+		i++
+	}
+	if i != end {
+		return d, false
+	}
+	if d.verb == 'v' {
+		f.sharpV, f.sharp = f.sharp, false
+		f.plusV, f.plus = f.plus, false
+	}
+	// An explicit alignment replaces zero padding
+	if d.align != 0 {
+		f.zero = false
+	}
+	return d, true
+}
+
+func isAlign(c byte) bool {
+	return c == '<' || c == '>' || c == '^'
+}
+
+// printBraces formats the compiled directives with args.
+func (p *printer) printBraces(directives []directive, args []any) {
+	next := 0
+	for i := range directives {
+		d := &directives[i]
+		if !d.hasArg {
+			p.printDirective(d, nil)
+			continue
+		}
+		index := d.index
+		if index < 0 {
+			index = next
+			next++
+		}
+		if index >= len(args) {
+			p.buf.writeString(d.lit)
+			p.buf.writeString(percentBangString)
+			p.buf.writeRune(d.verb)
+			if d.index < 0 {
+				p.buf.writeString(missingString)
+			} else {
+				p.buf.writeString(badIndexString)
+			}
+			continue
+		}
+		arg := args[index]
+		if d.align == 0 && d.flags.widPresent && !d.flags.zero {
+			// Python pads numbers on the left and everything else on the right
+			aligned := *d
+			aligned.align = '<'
+			if isNumber(arg) {
+				aligned.align = '>'
+			}
+			p.printDirective(&aligned, arg)
+			continue
+		}
+		p.printDirective(d, arg)
+	}
+}
+
+// isNumber reports whether arg is of a basic numeric type.
+func isNumber(arg any) bool {
+	switch arg.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64, complex64, complex128:
+		return true
+	}
+	return false
+}
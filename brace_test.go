@@ -0,0 +1,70 @@
+package xprint_test
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func TestFormatBraces(t *testing.T) {
+	testCases := []struct {
+		format   string
+		args     []any
+		expected string
+	}{
+		{"{} and {}", []any{"a", 1}, "a and 1"},
+		{"{1} {0} {1}", []any{"a", "b"}, "b a b"},
+		{"[{:>10}]", []any{"right"}, "[     right]"},
+		{"[{:<10}]", []any{"left"}, "[left      ]"},
+		{"[{:^10}]", []any{"mid"}, "[   mid    ]"},
+		{"[{:*^9}]", []any{"mid"}, "[***mid***]"},
+		{"[{:·>6}]", []any{42}, "[····42]"},
+		{"[{:8}]", []any{"str"}, "[str     ]"},
+		{"[{:8}]", []any{42}, "[      42]"},
+		{"{:08.3f}", []any{3.14159}, "0003.142"},
+		{"{:x} {:X} {:#x}", []any{255, 255, 255}, "ff FF 0xff"},
+		{"{:+d}", []any{5}, "+5"},
+		{"{:,}", []any{1234567}, "1,234,567"},
+		{"{{literal}} {}", []any{1}, "{literal} 1"},
+		{"{} {}", []any{1}, "1 %!v(MISSING)"},
+		{"{3}", []any{1}, "%!v(BADINDEX)"},
+		{"{unclosed", nil, "{unclosed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if o := xprint.Format(tc.format, tc.args...); o != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, o)
+			}
+		})
+	}
+}
+
+func TestFloatWidth(t *testing.T) {
+	for _, format := range []string{"%08.3f", "%8.3f", "%-8.2f|", "%+.1f", "% .2f", "%+08.2f", "%8v"} {
+		for _, v := range []any{3.14159, -2.5, float32(1.5), math.Inf(1)} {
+			if expected, o := fmt.Sprintf(format, v), xprint.Printf(format, v); o != expected {
+				t.Errorf("%s with %v: expected %q, got %q", format, v, expected, o)
+			}
+		}
+	}
+	if o := xprint.Printf("%#x %#X", 255, 255); o != "0xff 0XFF" {
+		t.Errorf("Expected 0xff 0XFF, got %s", o)
+	}
+}
+
+// TestFormatManyTemplates uses more distinct formats than are cached.
+func TestFormatManyTemplates(t *testing.T) {
+	for round := range 2 {
+		for i := range 1500 {
+			format := "{:>" + strconv.Itoa(i%9+1) + "}" + strconv.Itoa(i)
+			expected := fmt.Sprintf("%"+strconv.Itoa(i%9+1)+"s%d", "x", i)
+			if o := xprint.Format(format, "x"); o != expected {
+				t.Fatalf("round %d: Expected %s, got %s", round, expected, o)
+			}
+		}
+	}
+}
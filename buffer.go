@@ -1,6 +1,7 @@
 package xprint
 
 import (
+	"slices"
 	"strings"
 	"unicode/utf8"
 )
@@ -15,6 +16,11 @@ func (b *buffer) LenMB() int {
 	return BtoMB(b.Len())
 }
 
+// grow makes room for n more bytes, so writing them reallocates at most once.
+func (b *buffer) grow(n int) {
+	*b = slices.Grow(*b, n)
+}
+
 func (b *buffer) write(p []byte) {
 	*b = append(*b, p...)
 }
@@ -0,0 +1,150 @@
+package xprint
+
+import (
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// printerBufferSize is the largest buffer a pooled printer keeps for
+	// itself. Larger buffers go to the size classes below.
+	printerBufferSize = 64 << 10
+
+	// The size classes are the powers of two from 64KB to 1GB
+	minClassShift = 16
+	maxClassShift = 30
+	numClasses    = maxClassShift - minClassShift + 1
+
+	// defaultBufferPoolLimit is the largest buffer pooled until
+	// SetBufferPoolLimit is called.
+	defaultBufferPoolLimit = 1 << 20
+)
+
+// bufPool holds buffers too large for a printer to keep, one pool per power
+// of two. Class i holds buffers of at least 1<<(i+minClassShift) bytes, so
+// any of them serves a request of that size. The pools store *[]byte, and
+// printers keep the pointers they take out for their next Put.
+var bufPool struct {
+	classes [numClasses]sync.Pool
+	stats   [numClasses]classCounters
+	drops   atomic.Uint64
+	limit   atomic.Int64
+}
+
+type classCounters struct {
+	gets, hits, puts atomic.Uint64
+}
+
+func init() {
+	bufPool.limit.Store(defaultBufferPoolLimit)
+}
+
+// PoolStats reports the use of the buffer pools.
+type PoolStats struct {
+	Limit   int              // capacity of the largest buffer pooled
+	Drops   uint64           // buffers over the limit left to the garbage collector
+	Classes []PoolClassStats // one entry per size class up to the limit
+}
+
+// PoolClassStats reports the use of one size class of the buffer pools.
+type PoolClassStats struct {
+	Size int    // capacity of the buffers in the class
+	Gets uint64 // requests for a buffer of this size
+	Hits uint64 // requests served by a pooled buffer
+	Puts uint64 // buffers returned to the class
+}
+
+// SetBufferPoolLimit sets the capacity of the largest output buffer kept for
+// reuse; larger buffers are left to the garbage collector. Buffers of up to
+// 64KB are always kept. Above that they are pooled by power-of-two size
+// class, so a burst of large outputs doesn't pin memory sized for the
+// largest of them. The default limit is 1MB and the maximum 1GB.
+func SetBufferPoolLimit(n int) {
+	bufPool.limit.Store(int64(min(max(n, 0), 1<<maxClassShift)))
+}
+
+// BufferPoolStats returns the request, hit and return counts of the buffer
+// size classes.
+func BufferPoolStats() PoolStats {
+	limit := int(bufPool.limit.Load())
+	stats := PoolStats{Limit: limit, Drops: bufPool.drops.Load()}
+	for i := range bufPool.stats {
+		size := 1 << (i + minClassShift)
+		if size > limit {
+			break
+		}
+		c := &bufPool.stats[i]
+		stats.Classes = append(stats.Classes, PoolClassStats{
+			Size: size,
+			Gets: c.gets.Load(),
+			Hits: c.hits.Load(),
+			Puts: c.puts.Load(),
+		})
+	}
+	return stats
+}
+
+// bufferPooled reports whether a buffer of capacity c is kept for reuse.
+func bufferPooled(c int) bool {
+	return c <= printerBufferSize || int64(c) <= bufPool.limit.Load()
+}
+
+// getBuffer returns an empty buffer with a capacity of at least n, taken from
+// its size class if one is pooled. Requests over the limit get a buffer of
+// exactly n bytes.
+func (p *printer) getBuffer(n int) []byte {
+	shift := max(bits.Len(uint(n-1)), minClassShift)
+	if int64(1)<<shift > bufPool.limit.Load() {
+		return make([]byte, 0, n)
+	}
+	i := shift - minClassShift
+	bufPool.stats[i].gets.Add(1)
+	if h, _ := bufPool.classes[i].Get().(*[]byte); h != nil {
+		bufPool.stats[i].hits.Add(1)
+		b := *h
+		*h = nil
+		p.holder = h
+		return b
+	}
+	return make([]byte, 0, 1<<shift)
+}
+
+// putBuffer returns b, which is larger than a printer keeps, to the size
+// class its capacity fills.
+func (p *printer) putBuffer(b []byte) {
+	if int64(cap(b)) > bufPool.limit.Load() {
+		bufPool.drops.Add(1)
+		return
+	}
+	i := bits.Len(uint(cap(b))) - 1 - minClassShift
+	h := p.holder
+	if h == nil {
+		h = new([]byte)
+	}
+	p.holder = nil
+	*h = b[:0]
+	bufPool.stats[i].puts.Add(1)
+	bufPool.classes[i].Put(h)
+}
+
+// grow makes room for n more bytes in p.buf. Buffers too large for the
+// printer come from the size classes; the printer's own buffer is set aside
+// until free.
+func (p *printer) grow(n int) {
+	need := len(p.buf) + n
+	if need <= cap(p.buf) {
+		return
+	}
+	if need <= printerBufferSize {
+		p.buf.grow(n)
+		return
+	}
+	b := append(p.getBuffer(need), p.buf...)
+	if cap(p.buf) > printerBufferSize {
+		p.putBuffer(p.buf)
+	} else if p.spare == nil {
+		p.spare = p.buf[:0]
+	}
+	p.buf = b
+}
@@ -0,0 +1,89 @@
+package xprint_test
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func classStats(t *testing.T, size int) xprint.PoolClassStats {
+	t.Helper()
+	for _, c := range xprint.BufferPoolStats().Classes {
+		if c.Size == size {
+			return c
+		}
+	}
+	t.Fatalf("no size class of %d bytes", size)
+	return xprint.PoolClassStats{}
+}
+
+// TestBufferPoolClasses checks that large outputs take their buffers from and
+// return them to the size class that fits, and that outputs over the limit
+// are dropped.
+func TestBufferPoolClasses(t *testing.T) {
+	defer xprint.SetBufferPoolLimit(1 << 20)
+	payload := strings.Repeat("p", 300<<10)
+
+	before := classStats(t, 512<<10)
+	for i := range 3 {
+		if _, err := xprint.Fprintf(io.Discard, "%d: %s|%s", i, payload, payload[:10<<10]); err != nil {
+			t.Fatal(err)
+		}
+		l := xprint.Leasef("%s %s", payload, payload[:8<<10])
+		if l.Len() != len(payload)+1+8<<10 {
+			t.Errorf("expected a lease of %d bytes, got %d", len(payload)+1+8<<10, l.Len())
+		}
+		l.Release()
+		// Small outputs in between keep using the printers' own buffers
+		if got, expected := xprint.Printf("%d %s", i, "small"), strconv.Itoa(i)+" small"; got != expected {
+			t.Errorf("expected %q, got %q", expected, got)
+		}
+	}
+	after := classStats(t, 512<<10)
+	if gets, puts := after.Gets-before.Gets, after.Puts-before.Puts; gets != 6 || puts != 6 {
+		t.Errorf("expected 6 gets and 6 puts of 512KB buffers, got %d and %d", gets, puts)
+	}
+	if after.Hits < before.Hits || after.Hits-before.Hits > 6 {
+		t.Errorf("unexpected hit count %d, was %d", after.Hits, before.Hits)
+	}
+
+	xprint.SetBufferPoolLimit(256 << 10)
+	stats := xprint.BufferPoolStats()
+	if stats.Limit != 256<<10 || len(stats.Classes) != 3 {
+		t.Errorf("expected 3 classes up to 256KB, got %+v", stats)
+	}
+	drops := stats.Drops
+	if _, err := xprint.Fprintf(io.Discard, "%s|%s", payload, payload[:10<<10]); err != nil {
+		t.Fatal(err)
+	}
+	if got := xprint.BufferPoolStats().Drops; got != drops+1 {
+		t.Errorf("expected the buffer over the limit to be dropped, drops went from %d to %d", drops, got)
+	}
+
+	xprint.SetBufferPoolLimit(0)
+	if stats := xprint.BufferPoolStats(); len(stats.Classes) != 0 {
+		t.Errorf("expected no size classes with a zero limit, got %+v", stats.Classes)
+	}
+}
+
+// TestLargeStringResults checks that string results of every size match
+// whether or not their buffers are pooled.
+func TestLargeStringResults(t *testing.T) {
+	defer xprint.SetBufferPoolLimit(1 << 20)
+	for _, limit := range []int{0, 1 << 20} {
+		xprint.SetBufferPoolLimit(limit)
+		for _, size := range []int{10, 60 << 10, 100 << 10, 700 << 10, 2 << 20} {
+			payload := strings.Repeat("s", size)
+			expected := "[" + payload + "|" + payload[:size/2] + "]"
+			if got := xprint.Sprintf("[%s|%s]", payload, payload[:size/2]); got != expected {
+				t.Errorf("limit %d, size %d: wrong output of %d bytes", limit, size, len(got))
+			}
+			if got := xprint.Errorf("[%s|%s]", payload, payload[:size/2]).Error(); got != expected {
+				t.Errorf("limit %d, size %d: wrong error of %d bytes", limit, size, len(got))
+			}
+		}
+	}
+}
@@ -0,0 +1,692 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Output modes of a struct or value, matching %v, %+v and %#v.
+type mode int
+
+const (
+	modeV mode = iota
+	modePlus
+	modeSharp
+)
+
+// generator holds the type-checked package and the code being written.
+type generator struct {
+	pkg     *types.Package
+	targets map[string]*types.Named
+	order   []*types.Named
+
+	buf      bytes.Buffer
+	imports  map[string]bool
+	vars     int
+	helper   bool         // xprintgenField is needed
+	inlining []types.Type // struct types whose fields are being written
+}
+
+// newGenerator parses and type-checks the package in dir, ignoring the files
+// a previous run generated, and resolves the requested types.
+func newGenerator(dir, outPath string, names []string) (*generator, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	testPath := strings.TrimSuffix(outPath, ".go") + "_test.go"
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		path := filepath.Join(dir, name)
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") ||
+			sameFile(path, outPath) || sameFile(path, testPath) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no Go files in " + dir)
+	}
+
+	var typeErrs []error
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(err error) { typeErrs = append(typeErrs, err) },
+	}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, nil)
+	if len(typeErrs) > 0 {
+		return nil, typeErrs[0]
+	}
+
+	g := &generator{pkg: pkg, targets: make(map[string]*types.Named)}
+	for _, name := range names {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in package %s", name, pkg.Name())
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			return nil, fmt.Errorf("%s: only non-generic defined types are supported", name)
+		}
+		switch u := named.Underlying().(type) {
+		case *types.Struct:
+			for i := range u.NumFields() {
+				if u.Field(i).Name() == "_" {
+					return nil, fmt.Errorf("%s: blank fields are not supported", name)
+				}
+			}
+		case *types.Basic:
+			if u.Info()&types.IsInteger == 0 {
+				return nil, fmt.Errorf("%s: enums must have an integer underlying type", name)
+			}
+		default:
+			return nil, fmt.Errorf("%s: only struct and integer types are supported", name)
+		}
+		g.targets[name] = named
+		g.order = append(g.order, named)
+	}
+	return g, nil
+}
+
+func sameFile(a, b string) bool {
+	a, errA := filepath.Abs(a)
+	b, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && a == b
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// typeString returns t spelled the way reflect.Type.String spells it, or
+// false for types the generator does not spell, such as interfaces and
+// struct literals.
+func (g *generator) typeString(t types.Type) (string, bool) {
+	switch t := types.Unalias(t).(type) {
+	case *types.Named:
+		if t.TypeArgs().Len() > 0 {
+			return "", false
+		}
+		if t.Obj().Pkg() == nil {
+			return t.Obj().Name(), true
+		}
+		return t.Obj().Pkg().Name() + "." + t.Obj().Name(), true
+	case *types.Basic:
+		switch t.Kind() {
+		case types.Uint8:
+			return "uint8", true
+		case types.Int32:
+			return "int32", true
+		}
+		return t.Name(), t.Info()&types.IsUntyped == 0
+	case *types.Pointer:
+		s, ok := g.typeString(t.Elem())
+		return "*" + s, ok
+	case *types.Slice:
+		s, ok := g.typeString(t.Elem())
+		return "[]" + s, ok
+	case *types.Array:
+		s, ok := g.typeString(t.Elem())
+		return "[" + strconv.FormatInt(t.Len(), 10) + "]" + s, ok
+	case *types.Map:
+		k, okK := g.typeString(t.Key())
+		v, okV := g.typeString(t.Elem())
+		return "map[" + k + "]" + v, okK && okV
+	}
+	return "", false
+}
+
+// generate returns the formatted source of the output file.
+func (g *generator) generate() ([]byte, error) {
+	g.imports = map[string]bool{"strconv": true}
+	for _, named := range g.order {
+		if _, ok := named.Underlying().(*types.Struct); ok {
+			g.genStruct(named)
+		} else if err := g.genEnum(named); err != nil {
+			return nil, err
+		}
+	}
+	if g.helper {
+		g.genHelper()
+	}
+
+	body := g.buf.Bytes()
+	g.buf = bytes.Buffer{}
+	g.printf("// Code generated by xprintgen; DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", g.pkg.Name())
+	g.printImports()
+	g.buf.Write(body)
+	return format.Source(g.buf.Bytes())
+}
+
+func (g *generator) printImports() {
+	paths := make([]string, 0, len(g.imports))
+	for path, used := range g.imports {
+		if used {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+	g.printf("import (\n")
+	for _, path := range paths {
+		g.printf("\t%q\n", path)
+	}
+	g.printf(")\n\n")
+}
+
+// genStruct writes AppendVerb and AppendFormat for a struct type.
+func (g *generator) genStruct(named *types.Named) {
+	name := named.Obj().Name()
+	st := named.Underlying().(*types.Struct) //nolint:forcetypeassert // checked in newGenerator
+	plain := "xprintgen" + name
+	typeName, _ := g.typeString(named)
+	g.imports["fmt"] = true
+	g.inlining = []types.Type{named}
+
+	g.printf("// %s has the fields of %s without its methods, for the verbs fmt\n", plain, name)
+	g.printf("// applies to each field.\n")
+	g.printf("type %s %s\n\n", plain, name)
+
+	g.printf("// AppendVerb appends x as fmt formats it with %%v, or with %%+v and %%#v\n")
+	g.printf("// when plus or sharp is set. It implements xprint.VerbAppender.\n")
+	g.printf("func (x %s) AppendVerb(dst []byte, verb rune, plus, sharp bool) []byte {\n", name)
+	g.printf("if verb != 'v' {\nreturn fmt.Appendf(dst, \"%%\"+string(verb), %s(x))\n}\n", plain)
+	g.printf("switch {\ncase sharp:\n")
+	g.genFields("x", st, modeSharp, typeName, true)
+	g.printf("return dst\ncase plus:\n")
+	g.genFields("x", st, modePlus, "", true)
+	g.printf("return dst\n}\n")
+	g.genFields("x", st, modeV, "", true)
+	g.printf("return dst\n}\n\n")
+
+	g.genAppendFormat(name)
+}
+
+func (g *generator) genAppendFormat(name string) {
+	g.printf("// AppendFormat implements xprint.Appender.\n")
+	g.printf("func (x %s) AppendFormat(dst []byte, verb rune) []byte {\n", name)
+	g.printf("return x.AppendVerb(dst, verb, false, false)\n}\n\n")
+}
+
+// genFields writes the statements appending expr, a value of struct type st,
+// in braces. exported reports whether fmt may call methods on expr.
+func (g *generator) genFields(expr string, st *types.Struct, m mode, typeName string, exported bool) {
+	g.printf("dst = append(dst, %q...)\n", typeName+"{")
+	for i := range st.NumFields() {
+		f := st.Field(i)
+		var prefix string
+		switch {
+		case i > 0 && m == modeSharp:
+			prefix = ", "
+		case i > 0:
+			prefix = " "
+		}
+		if m != modeV {
+			prefix += f.Name() + ":"
+		}
+		if prefix != "" {
+			g.printf("dst = append(dst, %q...)\n", prefix)
+		}
+		g.genValue(expr+"."+f.Name(), f.Type(), exported && f.Exported(), m)
+	}
+	g.printf("dst = append(dst, '}')\n")
+}
+
+// genValue writes the statements appending expr, of type t, as fmt prints a
+// struct field or an element in mode m. exported reports whether fmt may
+// call methods on it.
+func (g *generator) genValue(expr string, t types.Type, exported bool, m mode) {
+	if exported {
+		call, ok := g.methodCall(t, m)
+		switch {
+		case !ok:
+			g.genFallback(expr, exported, m)
+			return
+		case call == "AppendVerb":
+			g.printf("dst = %s.AppendVerb(dst, 'v', %t, %t)\n", expr, m == modePlus, m == modeSharp)
+			return
+		case call != "":
+			g.printf("dst = append(dst, %s.%s()...)\n", expr, call)
+			return
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if g.basicSupported(u) {
+			g.genBasic(expr, u, m)
+			return
+		}
+	case *types.Slice:
+		if g.genList(expr, t, u.Elem(), true, exported, m) {
+			return
+		}
+	case *types.Array:
+		if g.genList(expr, t, u.Elem(), false, exported, m) {
+			return
+		}
+	case *types.Map:
+		if g.genMap(expr, t, u, exported, m) {
+			return
+		}
+	case *types.Pointer:
+		if g.genPointer(expr, t, m) {
+			return
+		}
+	case *types.Struct:
+		if g.genStructValue(expr, t, u, exported, m) {
+			return
+		}
+	}
+	g.genFallback(expr, exported, m)
+}
+
+// methodCall returns the method fmt calls to format a value of type t in
+// mode m, or "" if fmt prints the value itself. ok is false when that
+// depends on the value: for interfaces, fmt.Formatter implementations and
+// pointers, whose nil receivers fmt catches.
+func (g *generator) methodCall(t types.Type, m mode) (call string, ok bool) {
+	if named, isNamed := t.(*types.Named); isNamed && g.isTarget(named) {
+		return "AppendVerb", true
+	}
+	if types.IsInterface(t) {
+		return "", false
+	}
+	ms := types.NewMethodSet(t)
+	if sel := ms.Lookup(nil, "Format"); sel != nil && sel.Type().(*types.Signature).Params().Len() == 2 { //nolint:forcetypeassert // methods have signatures
+		return "", false
+	}
+	switch {
+	case m == modeSharp:
+		if returnsString(ms, "GoString") {
+			call = "GoString"
+		}
+	case returnsString(ms, "Error"):
+		call = "Error"
+	case returnsString(ms, "String"):
+		call = "String"
+	}
+	if _, isPtr := t.Underlying().(*types.Pointer); isPtr && call != "" {
+		return "", false
+	}
+	return call, true
+}
+
+// returnsString reports whether ms has a method name of type func() string.
+func returnsString(ms *types.MethodSet, name string) bool {
+	sel := ms.Lookup(nil, name)
+	if sel == nil {
+		return false
+	}
+	sig := sel.Type().(*types.Signature) //nolint:forcetypeassert // methods have signatures
+	return sig.Params().Len() == 0 && sig.Results().Len() == 1 &&
+		types.Identical(sig.Results().At(0).Type(), types.Typ[types.String])
+}
+
+func (g *generator) isTarget(named *types.Named) bool {
+	return g.targets[named.Obj().Name()] == named && named.Obj().Pkg() == g.pkg
+}
+
+func (g *generator) basicSupported(b *types.Basic) bool {
+	info := b.Info()
+	return info&(types.IsBoolean|types.IsInteger|types.IsFloat|types.IsString) != 0 && info&types.IsUntyped == 0
+}
+
+func (g *generator) genBasic(expr string, b *types.Basic, m mode) {
+	info := b.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		g.printf("dst = strconv.AppendBool(dst, bool(%s))\n", expr)
+	case info&types.IsUnsigned != 0:
+		if m == modeSharp {
+			g.printf("dst = append(dst, \"0x\"...)\ndst = strconv.AppendUint(dst, uint64(%s), 16)\n", expr)
+		} else {
+			g.printf("dst = strconv.AppendUint(dst, uint64(%s), 10)\n", expr)
+		}
+	case info&types.IsInteger != 0:
+		g.printf("dst = strconv.AppendInt(dst, int64(%s), 10)\n", expr)
+	case info&types.IsFloat != 0:
+		size := 64
+		if b.Kind() == types.Float32 {
+			size = 32
+		}
+		g.printf("dst = strconv.AppendFloat(dst, float64(%s), 'g', -1, %d)\n", expr, size)
+	case info&types.IsString != 0:
+		if m == modeSharp {
+			g.printf("dst = strconv.AppendQuote(dst, string(%s))\n", expr)
+		} else {
+			g.printf("dst = append(dst, string(%s)...)\n", expr)
+		}
+	}
+}
+
+// genList writes the loop appending the elements of a slice or array. Byte
+// slices and arrays are left to fmt, which formats them specially.
+func (g *generator) genList(expr string, t, elem types.Type, slice, exported bool, m mode) bool {
+	if b, ok := elem.Underlying().(*types.Basic); ok && b.Kind() == types.Uint8 {
+		return false
+	}
+	typeName, ok := g.typeString(t)
+	if m == modeSharp && !ok {
+		return false
+	}
+	g.vars++
+	e := "e" + strconv.Itoa(g.vars)
+	idx := "i" + strconv.Itoa(g.vars)
+	if m == modeSharp {
+		if slice {
+			g.printf("if %s == nil {\ndst = append(dst, %q...)\n} else {\n", expr, typeName+"(nil)")
+		}
+		g.printf("dst = append(dst, %q...)\n", typeName+"{")
+		g.printf("for %s, %s := range %s {\nif %s > 0 {\ndst = append(dst, \", \"...)\n}\n", idx, e, expr, idx)
+		g.genValue(e, elem, exported, m)
+		g.printf("}\ndst = append(dst, '}')\n")
+		if slice {
+			g.printf("}\n")
+		}
+		return true
+	}
+	g.printf("dst = append(dst, '[')\n")
+	g.printf("for %s, %s := range %s {\nif %s > 0 {\ndst = append(dst, ' ')\n}\n", idx, e, expr, idx)
+	g.genValue(e, elem, exported, m)
+	g.printf("}\ndst = append(dst, ']')\n")
+	return true
+}
+
+// genMap writes the statements appending a map in key order, as fmt prints
+// maps. The keys are sorted in an array on the stack for small maps. Only
+// string and integer keys the generated file can name are supported.
+func (g *generator) genMap(expr string, t types.Type, u *types.Map, exported bool, m mode) bool {
+	key, ok := u.Key().Underlying().(*types.Basic)
+	if !ok || key.Info()&(types.IsInteger|types.IsString) == 0 {
+		return false
+	}
+	if named, isNamed := u.Key().(*types.Named); isNamed && named.Obj().Pkg() != g.pkg {
+		return false
+	}
+	typeName, ok := g.typeString(t)
+	if m == modeSharp && !ok {
+		return false
+	}
+	g.imports["slices"] = true
+	g.vars++
+	n := strconv.Itoa(g.vars)
+	arr, keys, idx, k := "a"+n, "keys"+n, "i"+n, "k"+n
+	if m == modeSharp {
+		g.printf("if %s == nil {\ndst = append(dst, %q...)\n} else {\n", expr, typeName+"(nil)")
+		g.printf("dst = append(dst, %q...)\n", typeName+"{")
+	} else {
+		g.printf("dst = append(dst, \"map[\"...)\n")
+	}
+	g.printf("var %s [16]%s\n%s := %s[:0]\n", arr, g.localType(u.Key()), keys, arr)
+	g.printf("for %s := range %s {\n%s = append(%s, %s)\n}\nslices.Sort(%s)\n", k, expr, keys, keys, k, keys)
+	g.printf("for %s, %s := range %s {\nif %s > 0 {\n", idx, k, keys, idx)
+	if m == modeSharp {
+		g.printf("dst = append(dst, \", \"...)\n}\n")
+	} else {
+		g.printf("dst = append(dst, ' ')\n}\n")
+	}
+	g.genValue(k, u.Key(), exported, m)
+	g.printf("dst = append(dst, ':')\n")
+	g.genValue(expr+"["+k+"]", u.Elem(), exported, m)
+	if m == modeSharp {
+		g.printf("}\ndst = append(dst, '}')\n}\n")
+	} else {
+		g.printf("}\ndst = append(dst, ']')\n")
+	}
+	return true
+}
+
+// genPointer writes the address fmt prints for a pointer below the top
+// level.
+func (g *generator) genPointer(expr string, t types.Type, m mode) bool {
+	typeName, ok := g.typeString(t)
+	if m == modeSharp && !ok {
+		return false
+	}
+	g.imports["unsafe"] = true
+	null := "<nil>"
+	if m == modeSharp {
+		null = "nil"
+		g.printf("dst = append(dst, %q...)\n", "("+typeName+")(")
+	}
+	g.printf("if %s == nil {\ndst = append(dst, %q...)\n} else {\n", expr, null)
+	g.printf("dst = append(dst, \"0x\"...)\ndst = strconv.AppendUint(dst, uint64(uintptr(unsafe.Pointer(%s))), 16)\n}\n", expr)
+	if m == modeSharp {
+		g.printf("dst = append(dst, ')')\n")
+	}
+	return true
+}
+
+// genStructValue writes the fields of a struct fmt prints without calling
+// methods. Structs with fields the generated file cannot reach, and structs
+// already being written, which would recurse forever, are left to fmt.
+func (g *generator) genStructValue(expr string, t types.Type, st *types.Struct, exported bool, m mode) bool {
+	typeName, ok := g.typeString(t)
+	if m == modeSharp && !ok {
+		return false
+	}
+	for _, outer := range g.inlining {
+		if types.Identical(outer, t) {
+			return false
+		}
+	}
+	for i := range st.NumFields() {
+		if f := st.Field(i); f.Name() == "_" || !f.Exported() && f.Pkg() != g.pkg {
+			return false
+		}
+	}
+	if m != modeSharp {
+		typeName = ""
+	}
+	g.inlining = append(g.inlining, t)
+	g.genFields(expr, st, m, typeName, exported)
+	g.inlining = g.inlining[:len(g.inlining)-1]
+	return true
+}
+
+func (g *generator) genFallback(expr string, exported bool, m mode) {
+	g.helper = true
+	g.printf("dst = xprintgenField(dst, %t, %t, %t, %s)\n", m == modePlus, m == modeSharp, exported, expr)
+}
+
+// genHelper writes the function formatting fields the generator does not
+// handle itself. Wrapping the value in a one-field struct makes fmt print it
+// exactly as it prints a nested field.
+func (g *generator) genHelper() {
+	g.imports["bytes"] = true
+	g.printf(`// xprintgenField appends v as fmt formats a struct field holding it.
+func xprintgenField[T any](dst []byte, plus, sharp, exported bool, v T) []byte {
+	format := "%%v"
+	switch {
+	case sharp:
+		format = "%%#v"
+	case plus:
+		format = "%%+v"
+	}
+	start := len(dst)
+	marker := []byte("{X:")
+	if exported {
+		dst = fmt.Appendf(dst, format, struct{ X T }{v})
+	} else {
+		marker = []byte("{x:")
+		dst = fmt.Appendf(dst, format, struct{ x T }{v})
+	}
+	off := 1
+	if plus || sharp {
+		off = bytes.Index(dst[start:], marker) + len(marker)
+	}
+	n := copy(dst[start:], dst[start+off:len(dst)-1])
+	return dst[:start+n]
+}
+`)
+}
+
+// enumValue is one constant of an enum type.
+type enumValue struct {
+	name  string
+	value int64 // the bits of the value for unsigned types
+}
+
+// enumValues returns the constants of type t in the package, sorted by value
+// with duplicates removed, keeping the first name declared.
+func (g *generator) enumValues(t *types.Named, unsigned bool) []enumValue {
+	var values []enumValue
+	scope := g.pkg.Scope()
+	type declared struct {
+		enumValue
+		pos token.Pos
+	}
+	var all []declared
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), t) || name == "_" {
+			continue
+		}
+		var v int64
+		if unsigned {
+			u, _ := constant.Uint64Val(c.Val())
+			v = int64(u) //nolint:gosec // bits are kept
+		} else {
+			v, _ = constant.Int64Val(c.Val())
+		}
+		all = append(all, declared{enumValue{name, v}, c.Pos()})
+	}
+	slices.SortStableFunc(all, func(a, b declared) int {
+		if a.value != b.value {
+			if unsigned {
+				if uint64(a.value) < uint64(b.value) {
+					return -1
+				}
+				return 1
+			}
+			if a.value < b.value {
+				return -1
+			}
+			return 1
+		}
+		return int(a.pos - b.pos)
+	})
+	for i, d := range all {
+		if i > 0 && d.value == all[i-1].value {
+			continue
+		}
+		values = append(values, d.enumValue)
+	}
+	return values
+}
+
+// genEnum writes String, unless declared already, AppendVerb and AppendFormat
+// for an integer type.
+func (g *generator) genEnum(named *types.Named) error {
+	name := named.Obj().Name()
+	unsigned := named.Underlying().(*types.Basic).Info()&types.IsUnsigned != 0 //nolint:forcetypeassert // checked in newGenerator
+	values := g.enumValues(named, unsigned)
+	if len(values) == 0 {
+		return fmt.Errorf("%s: no constants of this type found", name)
+	}
+	g.imports["fmt"] = true
+
+	num := "strconv.AppendInt(dst, int64(x), 10)"
+	format := "strconv.FormatInt(int64(x), 10)"
+	if unsigned {
+		num = "strconv.AppendUint(dst, uint64(x), 10)"
+		format = "strconv.FormatUint(uint64(x), 10)"
+	}
+
+	if types.NewMethodSet(named).Lookup(nil, "String") == nil {
+		var names strings.Builder
+		offsets := make([]int, 0, len(values)+1)
+		for _, v := range values {
+			offsets = append(offsets, names.Len())
+			names.WriteString(v.name)
+		}
+		offsets = append(offsets, names.Len())
+
+		g.printf("const _%s_name = %q\n\n", name, names.String())
+		if contiguous(values) {
+			g.printf("var _%s_index = [...]uint%d{", name, indexBits(names.Len()))
+			for i, off := range offsets {
+				if i > 0 {
+					g.printf(", ")
+				}
+				g.printf("%d", off)
+			}
+			g.printf("}\n\n")
+		}
+		g.printf("// String returns the name of the %s constant x.\n", name)
+		g.printf("func (x %s) String() string {\n", name)
+		if contiguous(values) {
+			if unsigned {
+				g.printf("if i := uint64(x) - %d; i < %d {\n", uint64(values[0].value), len(values))
+			} else {
+				g.printf("if i := int64(x) - %d; i >= 0 && i < %d {\n", values[0].value, len(values))
+			}
+			g.printf("return _%s_name[_%s_index[i]:_%s_index[i+1]]\n}\n", name, name, name)
+		} else {
+			g.printf("switch x {\n")
+			for i, v := range values {
+				g.printf("case %s:\nreturn _%s_name[%d:%d]\n", v.name, name, offsets[i], offsets[i+1])
+			}
+			g.printf("}\n")
+		}
+		g.printf("return %q + %s + \")\"\n}\n\n", name+"(", format)
+	}
+
+	g.printf("// AppendVerb appends x as fmt formats it. It implements xprint.VerbAppender.\n")
+	g.printf("func (x %s) AppendVerb(dst []byte, verb rune, plus, sharp bool) []byte {\n", name)
+	g.printf("switch {\ncase verb == 'v' && sharp:\n")
+	if unsigned {
+		g.printf("dst = append(dst, \"0x\"...)\nreturn strconv.AppendUint(dst, uint64(x), 16)\n")
+	} else {
+		g.printf("return %s\n", num)
+	}
+	g.printf("case verb == 'v' || verb == 's':\nreturn append(dst, x.String()...)\n")
+	g.printf("case verb == 'q':\nreturn strconv.AppendQuote(dst, x.String())\n")
+	g.printf("case verb == 'x' || verb == 'X':\ndigits := \"0123456789abcdef\"\n")
+	g.printf("if verb == 'X' {\ndigits = \"0123456789ABCDEF\"\n}\n")
+	g.printf("for _, c := range []byte(x.String()) {\ndst = append(dst, digits[c>>4], digits[c&0xf])\n}\nreturn dst\n")
+	g.printf("case verb == 'd':\nreturn %s\n}\n", num)
+	g.printf("return fmt.Appendf(dst, \"%%\"+string(verb), x)\n}\n\n")
+
+	g.genAppendFormat(name)
+	return nil
+}
+
+func contiguous(values []enumValue) bool {
+	for i := 1; i < len(values); i++ {
+		if values[i].value != values[0].value+int64(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func indexBits(n int) int {
+	switch {
+	case n < 1<<8:
+		return 8
+	case n < 1<<16:
+		return 16
+	}
+	return 32
+}
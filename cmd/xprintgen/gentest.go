@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"go/types"
+	"strings"
+)
+
+// testFormats are the formats the generated test compares against fmt.
+var testFormats = []string{"%v", "%+v", "%#v", "%s", "%d", "%x", "%q"}
+
+// generateTest returns the source of a test checking that xprint, through the
+// generated methods, prints zero and sample values of every type as fmt does.
+func (g *generator) generateTest() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by xprintgen; DO NOT EDIT.\n\n")
+	b.WriteString("package " + g.pkg.Name() + "\n\n")
+	b.WriteString("import (\n\"fmt\"\n\"testing\"\n\n\"gopkg.hlmpn.dev/pkg/xprint\"\n)\n\n")
+	b.WriteString("func TestXprintgen(t *testing.T) {\nvalues := []any{\n")
+	for _, named := range g.order {
+		name := named.Obj().Name()
+		if _, ok := named.Underlying().(*types.Struct); ok {
+			b.WriteString(name + "{},\n&" + name + "{},\n")
+			b.WriteString(g.sample(named, 0) + ",\n")
+			continue
+		}
+		unsigned := named.Underlying().(*types.Basic).Info()&types.IsUnsigned != 0 //nolint:forcetypeassert // checked in newGenerator
+		for _, v := range g.enumValues(named, unsigned) {
+			b.WriteString(v.name + ",\n")
+		}
+		b.WriteString(name + "(99),\n")
+	}
+	b.WriteString("}\n// fmt is the reference: xprint prints these types only through the\n")
+	b.WriteString("// generated methods, and its reflective printer is tested against fmt\n")
+	b.WriteString("for _, v := range values {\nfor _, format := range []string{")
+	for i, f := range testFormats {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(`"` + f + `"`)
+	}
+	b.WriteString("} {\n")
+	b.WriteString("if want, got := fmt.Sprintf(format, v), xprint.Sprintf(format, v); got != want {\n")
+	b.WriteString("t.Errorf(\"%s of %T: got %q, want %q\", format, v, got, want)\n}\n}\n}\n")
+
+	// Nil pointers only go through the generated methods for %v
+	var nilPtrs []string
+	for _, named := range g.order {
+		if _, ok := named.Underlying().(*types.Struct); ok {
+			nilPtrs = append(nilPtrs, "(*"+named.Obj().Name()+")(nil)")
+		}
+	}
+	if len(nilPtrs) > 0 {
+		b.WriteString("for _, v := range []any{" + strings.Join(nilPtrs, ", ") + "} {\n")
+		b.WriteString("for _, format := range []string{\"%v\", \"%+v\", \"%#v\"} {\n")
+		b.WriteString("if want, got := fmt.Sprintf(format, v), xprint.Sprintf(format, v); got != want {\n")
+		b.WriteString("t.Errorf(\"%s of %T: got %q, want %q\", format, v, got, want)\n}\n}\n}\n")
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+// sample returns a composite literal of struct type named with every field
+// the generator handles set to a non-zero value.
+func (g *generator) sample(named *types.Named, depth int) string {
+	st := named.Underlying().(*types.Struct) //nolint:forcetypeassert // only called for structs
+	var fields []string
+	for i := range st.NumFields() {
+		f := st.Field(i)
+		if lit := g.sampleValue(f.Type(), depth); lit != "" {
+			fields = append(fields, f.Name()+": "+lit)
+		}
+	}
+	return g.localType(named) + "{" + strings.Join(fields, ", ") + "}"
+}
+
+// sampleValue returns a non-zero literal of type t, or "" for types it cannot
+// spell without imports.
+func (g *generator) sampleValue(t types.Type, depth int) string {
+	if named, ok := t.(*types.Named); ok {
+		if g.targets[named.Obj().Name()] == named {
+			if _, isStruct := named.Underlying().(*types.Struct); isStruct {
+				if depth >= 2 {
+					return ""
+				}
+				return g.sample(named, depth+1)
+			}
+			unsigned := named.Underlying().(*types.Basic).Info()&types.IsUnsigned != 0 //nolint:forcetypeassert // enums are basic
+			values := g.enumValues(named, unsigned)
+			return values[len(values)-1].name
+		}
+		if named.Obj().Pkg() != g.pkg {
+			return ""
+		}
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		info := u.Info()
+		switch {
+		case info&types.IsBoolean != 0:
+			return "true"
+		case info&types.IsUnsigned != 0:
+			return "42"
+		case info&types.IsInteger != 0:
+			return "-42"
+		case info&types.IsFloat != 0:
+			return "1.5"
+		case info&types.IsString != 0:
+			return `"a \"b\"\n"`
+		}
+	case *types.Slice:
+		if _, ok := t.(*types.Slice); !ok {
+			return ""
+		}
+		elem := g.sampleValue(u.Elem(), depth)
+		if elem == "" {
+			return ""
+		}
+		return g.localType(t) + "{" + elem + ", " + elem + "}"
+	case *types.Array:
+		if _, ok := t.(*types.Array); !ok {
+			return ""
+		}
+		elem := g.sampleValue(u.Elem(), depth)
+		if elem == "" {
+			return ""
+		}
+		return g.localType(t) + "{" + elem + "}"
+	case *types.Map:
+		if _, ok := t.(*types.Map); !ok {
+			return ""
+		}
+		k1, k2 := g.sampleKeys(u.Key())
+		elem := g.sampleValue(u.Elem(), depth)
+		if k1 == "" || elem == "" {
+			return ""
+		}
+		return g.localType(t) + "{" + k1 + ": " + elem + ", " + k2 + ": " + elem + "}"
+	case *types.Pointer:
+		named, ok := u.Elem().(*types.Named)
+		if _, isPtr := t.(*types.Pointer); !isPtr || !ok || named.Obj().Pkg() != g.pkg {
+			return ""
+		}
+		if _, isStruct := named.Underlying().(*types.Struct); !isStruct || depth >= 2 {
+			return ""
+		}
+		return "&" + g.sample(named, depth+1)
+	case *types.Struct:
+		named, ok := t.(*types.Named)
+		if !ok || depth >= 2 {
+			return ""
+		}
+		return g.sample(named, depth+1)
+	}
+	return ""
+}
+
+// sampleKeys returns two distinct map keys of type t, the larger first so
+// the test sees them sorted, or "" if it cannot spell them.
+func (g *generator) sampleKeys(t types.Type) (string, string) {
+	if named, ok := t.(*types.Named); ok {
+		if !g.isTarget(named) {
+			return "", ""
+		}
+		if _, isStruct := named.Underlying().(*types.Struct); isStruct {
+			return "", ""
+		}
+		unsigned := named.Underlying().(*types.Basic).Info()&types.IsUnsigned != 0 //nolint:forcetypeassert // enums are basic
+		values := g.enumValues(named, unsigned)
+		if len(values) < 2 {
+			return "", ""
+		}
+		return values[len(values)-1].name, values[0].name
+	}
+	b, ok := t.(*types.Basic)
+	switch {
+	case !ok:
+	case b.Info()&types.IsString != 0:
+		return `"b"`, `"a \"b\"\n"`
+	case b.Info()&types.IsInteger != 0:
+		return "42", "7"
+	}
+	return "", ""
+}
+
+func (g *generator) localType(t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(g.pkg))
+}
@@ -0,0 +1,61 @@
+// Package example holds types formatted by code from xprintgen. The
+// generated test keeps the generator honest against fmt.
+package example
+
+import "time"
+
+//go:generate go run gopkg.hlmpn.dev/pkg/xprint/cmd/xprintgen -type=Order,Item,Status,Priority -test
+
+// Status is the state of an order.
+type Status int
+
+const (
+	StatusPending Status = iota
+	StatusPaid
+	StatusShipped
+)
+
+// Priority is an order priority with gaps between its values.
+type Priority uint8
+
+const (
+	PriorityLow    Priority = 1
+	PriorityNormal Priority = 5
+	PriorityHigh   Priority = 10
+)
+
+// Item is an order line.
+type Item struct {
+	SKU   string
+	Qty   uint
+	Price float64
+}
+
+// Address is a struct without generated methods.
+type Address struct {
+	Street string
+	Zip    int
+}
+
+// Order exercises every kind of field the generator handles.
+type Order struct {
+	ID       int64
+	Customer string
+	Status   Status
+	Priority Priority
+	Items    []Item
+	Tags     []string
+	Paid     bool
+	Weight   float32
+	Created  time.Time
+	Meta     map[string]int
+	Counts   map[Status]uint
+	Parent   *Order
+	Ship     Address
+	Dims     [3]float64
+	Extra    any
+	note     string
+	state    Status
+	lines    []Item
+	byStatus map[Status][]Item
+}
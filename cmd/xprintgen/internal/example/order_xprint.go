@@ -0,0 +1,655 @@
+// Code generated by xprintgen; DO NOT EDIT.
+
+package example
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"unsafe"
+)
+
+// xprintgenOrder has the fields of Order without its methods, for the verbs fmt
+// applies to each field.
+type xprintgenOrder Order
+
+// AppendVerb appends x as fmt formats it with %v, or with %+v and %#v
+// when plus or sharp is set. It implements xprint.VerbAppender.
+func (x Order) AppendVerb(dst []byte, verb rune, plus, sharp bool) []byte {
+	if verb != 'v' {
+		return fmt.Appendf(dst, "%"+string(verb), xprintgenOrder(x))
+	}
+	switch {
+	case sharp:
+		dst = append(dst, "example.Order{"...)
+		dst = append(dst, "ID:"...)
+		dst = strconv.AppendInt(dst, int64(x.ID), 10)
+		dst = append(dst, ", Customer:"...)
+		dst = strconv.AppendQuote(dst, string(x.Customer))
+		dst = append(dst, ", Status:"...)
+		dst = x.Status.AppendVerb(dst, 'v', false, true)
+		dst = append(dst, ", Priority:"...)
+		dst = x.Priority.AppendVerb(dst, 'v', false, true)
+		dst = append(dst, ", Items:"...)
+		if x.Items == nil {
+			dst = append(dst, "[]example.Item(nil)"...)
+		} else {
+			dst = append(dst, "[]example.Item{"...)
+			for i1, e1 := range x.Items {
+				if i1 > 0 {
+					dst = append(dst, ", "...)
+				}
+				dst = e1.AppendVerb(dst, 'v', false, true)
+			}
+			dst = append(dst, '}')
+		}
+		dst = append(dst, ", Tags:"...)
+		if x.Tags == nil {
+			dst = append(dst, "[]string(nil)"...)
+		} else {
+			dst = append(dst, "[]string{"...)
+			for i2, e2 := range x.Tags {
+				if i2 > 0 {
+					dst = append(dst, ", "...)
+				}
+				dst = strconv.AppendQuote(dst, string(e2))
+			}
+			dst = append(dst, '}')
+		}
+		dst = append(dst, ", Paid:"...)
+		dst = strconv.AppendBool(dst, bool(x.Paid))
+		dst = append(dst, ", Weight:"...)
+		dst = strconv.AppendFloat(dst, float64(x.Weight), 'g', -1, 32)
+		dst = append(dst, ", Created:"...)
+		dst = append(dst, x.Created.GoString()...)
+		dst = append(dst, ", Meta:"...)
+		if x.Meta == nil {
+			dst = append(dst, "map[string]int(nil)"...)
+		} else {
+			dst = append(dst, "map[string]int{"...)
+			var a3 [16]string
+			keys3 := a3[:0]
+			for k3 := range x.Meta {
+				keys3 = append(keys3, k3)
+			}
+			slices.Sort(keys3)
+			for i3, k3 := range keys3 {
+				if i3 > 0 {
+					dst = append(dst, ", "...)
+				}
+				dst = strconv.AppendQuote(dst, string(k3))
+				dst = append(dst, ':')
+				dst = strconv.AppendInt(dst, int64(x.Meta[k3]), 10)
+			}
+			dst = append(dst, '}')
+		}
+		dst = append(dst, ", Counts:"...)
+		if x.Counts == nil {
+			dst = append(dst, "map[example.Status]uint(nil)"...)
+		} else {
+			dst = append(dst, "map[example.Status]uint{"...)
+			var a4 [16]Status
+			keys4 := a4[:0]
+			for k4 := range x.Counts {
+				keys4 = append(keys4, k4)
+			}
+			slices.Sort(keys4)
+			for i4, k4 := range keys4 {
+				if i4 > 0 {
+					dst = append(dst, ", "...)
+				}
+				dst = k4.AppendVerb(dst, 'v', false, true)
+				dst = append(dst, ':')
+				dst = append(dst, "0x"...)
+				dst = strconv.AppendUint(dst, uint64(x.Counts[k4]), 16)
+			}
+			dst = append(dst, '}')
+		}
+		dst = append(dst, ", Parent:"...)
+		dst = append(dst, "(*example.Order)("...)
+		if x.Parent == nil {
+			dst = append(dst, "nil"...)
+		} else {
+			dst = append(dst, "0x"...)
+			dst = strconv.AppendUint(dst, uint64(uintptr(unsafe.Pointer(x.Parent))), 16)
+		}
+		dst = append(dst, ')')
+		dst = append(dst, ", Ship:"...)
+		dst = append(dst, "example.Address{"...)
+		dst = append(dst, "Street:"...)
+		dst = strconv.AppendQuote(dst, string(x.Ship.Street))
+		dst = append(dst, ", Zip:"...)
+		dst = strconv.AppendInt(dst, int64(x.Ship.Zip), 10)
+		dst = append(dst, '}')
+		dst = append(dst, ", Dims:"...)
+		dst = append(dst, "[3]float64{"...)
+		for i5, e5 := range x.Dims {
+			if i5 > 0 {
+				dst = append(dst, ", "...)
+			}
+			dst = strconv.AppendFloat(dst, float64(e5), 'g', -1, 64)
+		}
+		dst = append(dst, '}')
+		dst = append(dst, ", Extra:"...)
+		dst = xprintgenField(dst, false, true, true, x.Extra)
+		dst = append(dst, ", note:"...)
+		dst = strconv.AppendQuote(dst, string(x.note))
+		dst = append(dst, ", state:"...)
+		dst = strconv.AppendInt(dst, int64(x.state), 10)
+		dst = append(dst, ", lines:"...)
+		if x.lines == nil {
+			dst = append(dst, "[]example.Item(nil)"...)
+		} else {
+			dst = append(dst, "[]example.Item{"...)
+			for i6, e6 := range x.lines {
+				if i6 > 0 {
+					dst = append(dst, ", "...)
+				}
+				dst = append(dst, "example.Item{"...)
+				dst = append(dst, "SKU:"...)
+				dst = strconv.AppendQuote(dst, string(e6.SKU))
+				dst = append(dst, ", Qty:"...)
+				dst = append(dst, "0x"...)
+				dst = strconv.AppendUint(dst, uint64(e6.Qty), 16)
+				dst = append(dst, ", Price:"...)
+				dst = strconv.AppendFloat(dst, float64(e6.Price), 'g', -1, 64)
+				dst = append(dst, '}')
+			}
+			dst = append(dst, '}')
+		}
+		dst = append(dst, ", byStatus:"...)
+		if x.byStatus == nil {
+			dst = append(dst, "map[example.Status][]example.Item(nil)"...)
+		} else {
+			dst = append(dst, "map[example.Status][]example.Item{"...)
+			var a7 [16]Status
+			keys7 := a7[:0]
+			for k7 := range x.byStatus {
+				keys7 = append(keys7, k7)
+			}
+			slices.Sort(keys7)
+			for i7, k7 := range keys7 {
+				if i7 > 0 {
+					dst = append(dst, ", "...)
+				}
+				dst = strconv.AppendInt(dst, int64(k7), 10)
+				dst = append(dst, ':')
+				if x.byStatus[k7] == nil {
+					dst = append(dst, "[]example.Item(nil)"...)
+				} else {
+					dst = append(dst, "[]example.Item{"...)
+					for i8, e8 := range x.byStatus[k7] {
+						if i8 > 0 {
+							dst = append(dst, ", "...)
+						}
+						dst = append(dst, "example.Item{"...)
+						dst = append(dst, "SKU:"...)
+						dst = strconv.AppendQuote(dst, string(e8.SKU))
+						dst = append(dst, ", Qty:"...)
+						dst = append(dst, "0x"...)
+						dst = strconv.AppendUint(dst, uint64(e8.Qty), 16)
+						dst = append(dst, ", Price:"...)
+						dst = strconv.AppendFloat(dst, float64(e8.Price), 'g', -1, 64)
+						dst = append(dst, '}')
+					}
+					dst = append(dst, '}')
+				}
+			}
+			dst = append(dst, '}')
+		}
+		dst = append(dst, '}')
+		return dst
+	case plus:
+		dst = append(dst, "{"...)
+		dst = append(dst, "ID:"...)
+		dst = strconv.AppendInt(dst, int64(x.ID), 10)
+		dst = append(dst, " Customer:"...)
+		dst = append(dst, string(x.Customer)...)
+		dst = append(dst, " Status:"...)
+		dst = x.Status.AppendVerb(dst, 'v', true, false)
+		dst = append(dst, " Priority:"...)
+		dst = x.Priority.AppendVerb(dst, 'v', true, false)
+		dst = append(dst, " Items:"...)
+		dst = append(dst, '[')
+		for i9, e9 := range x.Items {
+			if i9 > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = e9.AppendVerb(dst, 'v', true, false)
+		}
+		dst = append(dst, ']')
+		dst = append(dst, " Tags:"...)
+		dst = append(dst, '[')
+		for i10, e10 := range x.Tags {
+			if i10 > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = append(dst, string(e10)...)
+		}
+		dst = append(dst, ']')
+		dst = append(dst, " Paid:"...)
+		dst = strconv.AppendBool(dst, bool(x.Paid))
+		dst = append(dst, " Weight:"...)
+		dst = strconv.AppendFloat(dst, float64(x.Weight), 'g', -1, 32)
+		dst = append(dst, " Created:"...)
+		dst = append(dst, x.Created.String()...)
+		dst = append(dst, " Meta:"...)
+		dst = append(dst, "map["...)
+		var a11 [16]string
+		keys11 := a11[:0]
+		for k11 := range x.Meta {
+			keys11 = append(keys11, k11)
+		}
+		slices.Sort(keys11)
+		for i11, k11 := range keys11 {
+			if i11 > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = append(dst, string(k11)...)
+			dst = append(dst, ':')
+			dst = strconv.AppendInt(dst, int64(x.Meta[k11]), 10)
+		}
+		dst = append(dst, ']')
+		dst = append(dst, " Counts:"...)
+		dst = append(dst, "map["...)
+		var a12 [16]Status
+		keys12 := a12[:0]
+		for k12 := range x.Counts {
+			keys12 = append(keys12, k12)
+		}
+		slices.Sort(keys12)
+		for i12, k12 := range keys12 {
+			if i12 > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = k12.AppendVerb(dst, 'v', true, false)
+			dst = append(dst, ':')
+			dst = strconv.AppendUint(dst, uint64(x.Counts[k12]), 10)
+		}
+		dst = append(dst, ']')
+		dst = append(dst, " Parent:"...)
+		if x.Parent == nil {
+			dst = append(dst, "<nil>"...)
+		} else {
+			dst = append(dst, "0x"...)
+			dst = strconv.AppendUint(dst, uint64(uintptr(unsafe.Pointer(x.Parent))), 16)
+		}
+		dst = append(dst, " Ship:"...)
+		dst = append(dst, "{"...)
+		dst = append(dst, "Street:"...)
+		dst = append(dst, string(x.Ship.Street)...)
+		dst = append(dst, " Zip:"...)
+		dst = strconv.AppendInt(dst, int64(x.Ship.Zip), 10)
+		dst = append(dst, '}')
+		dst = append(dst, " Dims:"...)
+		dst = append(dst, '[')
+		for i13, e13 := range x.Dims {
+			if i13 > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = strconv.AppendFloat(dst, float64(e13), 'g', -1, 64)
+		}
+		dst = append(dst, ']')
+		dst = append(dst, " Extra:"...)
+		dst = xprintgenField(dst, true, false, true, x.Extra)
+		dst = append(dst, " note:"...)
+		dst = append(dst, string(x.note)...)
+		dst = append(dst, " state:"...)
+		dst = strconv.AppendInt(dst, int64(x.state), 10)
+		dst = append(dst, " lines:"...)
+		dst = append(dst, '[')
+		for i14, e14 := range x.lines {
+			if i14 > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = append(dst, "{"...)
+			dst = append(dst, "SKU:"...)
+			dst = append(dst, string(e14.SKU)...)
+			dst = append(dst, " Qty:"...)
+			dst = strconv.AppendUint(dst, uint64(e14.Qty), 10)
+			dst = append(dst, " Price:"...)
+			dst = strconv.AppendFloat(dst, float64(e14.Price), 'g', -1, 64)
+			dst = append(dst, '}')
+		}
+		dst = append(dst, ']')
+		dst = append(dst, " byStatus:"...)
+		dst = append(dst, "map["...)
+		var a15 [16]Status
+		keys15 := a15[:0]
+		for k15 := range x.byStatus {
+			keys15 = append(keys15, k15)
+		}
+		slices.Sort(keys15)
+		for i15, k15 := range keys15 {
+			if i15 > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = strconv.AppendInt(dst, int64(k15), 10)
+			dst = append(dst, ':')
+			dst = append(dst, '[')
+			for i16, e16 := range x.byStatus[k15] {
+				if i16 > 0 {
+					dst = append(dst, ' ')
+				}
+				dst = append(dst, "{"...)
+				dst = append(dst, "SKU:"...)
+				dst = append(dst, string(e16.SKU)...)
+				dst = append(dst, " Qty:"...)
+				dst = strconv.AppendUint(dst, uint64(e16.Qty), 10)
+				dst = append(dst, " Price:"...)
+				dst = strconv.AppendFloat(dst, float64(e16.Price), 'g', -1, 64)
+				dst = append(dst, '}')
+			}
+			dst = append(dst, ']')
+		}
+		dst = append(dst, ']')
+		dst = append(dst, '}')
+		return dst
+	}
+	dst = append(dst, "{"...)
+	dst = strconv.AppendInt(dst, int64(x.ID), 10)
+	dst = append(dst, " "...)
+	dst = append(dst, string(x.Customer)...)
+	dst = append(dst, " "...)
+	dst = x.Status.AppendVerb(dst, 'v', false, false)
+	dst = append(dst, " "...)
+	dst = x.Priority.AppendVerb(dst, 'v', false, false)
+	dst = append(dst, " "...)
+	dst = append(dst, '[')
+	for i17, e17 := range x.Items {
+		if i17 > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = e17.AppendVerb(dst, 'v', false, false)
+	}
+	dst = append(dst, ']')
+	dst = append(dst, " "...)
+	dst = append(dst, '[')
+	for i18, e18 := range x.Tags {
+		if i18 > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = append(dst, string(e18)...)
+	}
+	dst = append(dst, ']')
+	dst = append(dst, " "...)
+	dst = strconv.AppendBool(dst, bool(x.Paid))
+	dst = append(dst, " "...)
+	dst = strconv.AppendFloat(dst, float64(x.Weight), 'g', -1, 32)
+	dst = append(dst, " "...)
+	dst = append(dst, x.Created.String()...)
+	dst = append(dst, " "...)
+	dst = append(dst, "map["...)
+	var a19 [16]string
+	keys19 := a19[:0]
+	for k19 := range x.Meta {
+		keys19 = append(keys19, k19)
+	}
+	slices.Sort(keys19)
+	for i19, k19 := range keys19 {
+		if i19 > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = append(dst, string(k19)...)
+		dst = append(dst, ':')
+		dst = strconv.AppendInt(dst, int64(x.Meta[k19]), 10)
+	}
+	dst = append(dst, ']')
+	dst = append(dst, " "...)
+	dst = append(dst, "map["...)
+	var a20 [16]Status
+	keys20 := a20[:0]
+	for k20 := range x.Counts {
+		keys20 = append(keys20, k20)
+	}
+	slices.Sort(keys20)
+	for i20, k20 := range keys20 {
+		if i20 > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = k20.AppendVerb(dst, 'v', false, false)
+		dst = append(dst, ':')
+		dst = strconv.AppendUint(dst, uint64(x.Counts[k20]), 10)
+	}
+	dst = append(dst, ']')
+	dst = append(dst, " "...)
+	if x.Parent == nil {
+		dst = append(dst, "<nil>"...)
+	} else {
+		dst = append(dst, "0x"...)
+		dst = strconv.AppendUint(dst, uint64(uintptr(unsafe.Pointer(x.Parent))), 16)
+	}
+	dst = append(dst, " "...)
+	dst = append(dst, "{"...)
+	dst = append(dst, string(x.Ship.Street)...)
+	dst = append(dst, " "...)
+	dst = strconv.AppendInt(dst, int64(x.Ship.Zip), 10)
+	dst = append(dst, '}')
+	dst = append(dst, " "...)
+	dst = append(dst, '[')
+	for i21, e21 := range x.Dims {
+		if i21 > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = strconv.AppendFloat(dst, float64(e21), 'g', -1, 64)
+	}
+	dst = append(dst, ']')
+	dst = append(dst, " "...)
+	dst = xprintgenField(dst, false, false, true, x.Extra)
+	dst = append(dst, " "...)
+	dst = append(dst, string(x.note)...)
+	dst = append(dst, " "...)
+	dst = strconv.AppendInt(dst, int64(x.state), 10)
+	dst = append(dst, " "...)
+	dst = append(dst, '[')
+	for i22, e22 := range x.lines {
+		if i22 > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = append(dst, "{"...)
+		dst = append(dst, string(e22.SKU)...)
+		dst = append(dst, " "...)
+		dst = strconv.AppendUint(dst, uint64(e22.Qty), 10)
+		dst = append(dst, " "...)
+		dst = strconv.AppendFloat(dst, float64(e22.Price), 'g', -1, 64)
+		dst = append(dst, '}')
+	}
+	dst = append(dst, ']')
+	dst = append(dst, " "...)
+	dst = append(dst, "map["...)
+	var a23 [16]Status
+	keys23 := a23[:0]
+	for k23 := range x.byStatus {
+		keys23 = append(keys23, k23)
+	}
+	slices.Sort(keys23)
+	for i23, k23 := range keys23 {
+		if i23 > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = strconv.AppendInt(dst, int64(k23), 10)
+		dst = append(dst, ':')
+		dst = append(dst, '[')
+		for i24, e24 := range x.byStatus[k23] {
+			if i24 > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = append(dst, "{"...)
+			dst = append(dst, string(e24.SKU)...)
+			dst = append(dst, " "...)
+			dst = strconv.AppendUint(dst, uint64(e24.Qty), 10)
+			dst = append(dst, " "...)
+			dst = strconv.AppendFloat(dst, float64(e24.Price), 'g', -1, 64)
+			dst = append(dst, '}')
+		}
+		dst = append(dst, ']')
+	}
+	dst = append(dst, ']')
+	dst = append(dst, '}')
+	return dst
+}
+
+// AppendFormat implements xprint.Appender.
+func (x Order) AppendFormat(dst []byte, verb rune) []byte {
+	return x.AppendVerb(dst, verb, false, false)
+}
+
+// xprintgenItem has the fields of Item without its methods, for the verbs fmt
+// applies to each field.
+type xprintgenItem Item
+
+// AppendVerb appends x as fmt formats it with %v, or with %+v and %#v
+// when plus or sharp is set. It implements xprint.VerbAppender.
+func (x Item) AppendVerb(dst []byte, verb rune, plus, sharp bool) []byte {
+	if verb != 'v' {
+		return fmt.Appendf(dst, "%"+string(verb), xprintgenItem(x))
+	}
+	switch {
+	case sharp:
+		dst = append(dst, "example.Item{"...)
+		dst = append(dst, "SKU:"...)
+		dst = strconv.AppendQuote(dst, string(x.SKU))
+		dst = append(dst, ", Qty:"...)
+		dst = append(dst, "0x"...)
+		dst = strconv.AppendUint(dst, uint64(x.Qty), 16)
+		dst = append(dst, ", Price:"...)
+		dst = strconv.AppendFloat(dst, float64(x.Price), 'g', -1, 64)
+		dst = append(dst, '}')
+		return dst
+	case plus:
+		dst = append(dst, "{"...)
+		dst = append(dst, "SKU:"...)
+		dst = append(dst, string(x.SKU)...)
+		dst = append(dst, " Qty:"...)
+		dst = strconv.AppendUint(dst, uint64(x.Qty), 10)
+		dst = append(dst, " Price:"...)
+		dst = strconv.AppendFloat(dst, float64(x.Price), 'g', -1, 64)
+		dst = append(dst, '}')
+		return dst
+	}
+	dst = append(dst, "{"...)
+	dst = append(dst, string(x.SKU)...)
+	dst = append(dst, " "...)
+	dst = strconv.AppendUint(dst, uint64(x.Qty), 10)
+	dst = append(dst, " "...)
+	dst = strconv.AppendFloat(dst, float64(x.Price), 'g', -1, 64)
+	dst = append(dst, '}')
+	return dst
+}
+
+// AppendFormat implements xprint.Appender.
+func (x Item) AppendFormat(dst []byte, verb rune) []byte {
+	return x.AppendVerb(dst, verb, false, false)
+}
+
+const _Status_name = "StatusPendingStatusPaidStatusShipped"
+
+var _Status_index = [...]uint8{0, 13, 23, 36}
+
+// String returns the name of the Status constant x.
+func (x Status) String() string {
+	if i := int64(x) - 0; i >= 0 && i < 3 {
+		return _Status_name[_Status_index[i]:_Status_index[i+1]]
+	}
+	return "Status(" + strconv.FormatInt(int64(x), 10) + ")"
+}
+
+// AppendVerb appends x as fmt formats it. It implements xprint.VerbAppender.
+func (x Status) AppendVerb(dst []byte, verb rune, plus, sharp bool) []byte {
+	switch {
+	case verb == 'v' && sharp:
+		return strconv.AppendInt(dst, int64(x), 10)
+	case verb == 'v' || verb == 's':
+		return append(dst, x.String()...)
+	case verb == 'q':
+		return strconv.AppendQuote(dst, x.String())
+	case verb == 'x' || verb == 'X':
+		digits := "0123456789abcdef"
+		if verb == 'X' {
+			digits = "0123456789ABCDEF"
+		}
+		for _, c := range []byte(x.String()) {
+			dst = append(dst, digits[c>>4], digits[c&0xf])
+		}
+		return dst
+	case verb == 'd':
+		return strconv.AppendInt(dst, int64(x), 10)
+	}
+	return fmt.Appendf(dst, "%"+string(verb), x)
+}
+
+// AppendFormat implements xprint.Appender.
+func (x Status) AppendFormat(dst []byte, verb rune) []byte {
+	return x.AppendVerb(dst, verb, false, false)
+}
+
+const _Priority_name = "PriorityLowPriorityNormalPriorityHigh"
+
+// String returns the name of the Priority constant x.
+func (x Priority) String() string {
+	switch x {
+	case PriorityLow:
+		return _Priority_name[0:11]
+	case PriorityNormal:
+		return _Priority_name[11:25]
+	case PriorityHigh:
+		return _Priority_name[25:37]
+	}
+	return "Priority(" + strconv.FormatUint(uint64(x), 10) + ")"
+}
+
+// AppendVerb appends x as fmt formats it. It implements xprint.VerbAppender.
+func (x Priority) AppendVerb(dst []byte, verb rune, plus, sharp bool) []byte {
+	switch {
+	case verb == 'v' && sharp:
+		dst = append(dst, "0x"...)
+		return strconv.AppendUint(dst, uint64(x), 16)
+	case verb == 'v' || verb == 's':
+		return append(dst, x.String()...)
+	case verb == 'q':
+		return strconv.AppendQuote(dst, x.String())
+	case verb == 'x' || verb == 'X':
+		digits := "0123456789abcdef"
+		if verb == 'X' {
+			digits = "0123456789ABCDEF"
+		}
+		for _, c := range []byte(x.String()) {
+			dst = append(dst, digits[c>>4], digits[c&0xf])
+		}
+		return dst
+	case verb == 'd':
+		return strconv.AppendUint(dst, uint64(x), 10)
+	}
+	return fmt.Appendf(dst, "%"+string(verb), x)
+}
+
+// AppendFormat implements xprint.Appender.
+func (x Priority) AppendFormat(dst []byte, verb rune) []byte {
+	return x.AppendVerb(dst, verb, false, false)
+}
+
+// xprintgenField appends v as fmt formats a struct field holding it.
+func xprintgenField[T any](dst []byte, plus, sharp, exported bool, v T) []byte {
+	format := "%v"
+	switch {
+	case sharp:
+		format = "%#v"
+	case plus:
+		format = "%+v"
+	}
+	start := len(dst)
+	marker := []byte("{X:")
+	if exported {
+		dst = fmt.Appendf(dst, format, struct{ X T }{v})
+	} else {
+		marker = []byte("{x:")
+		dst = fmt.Appendf(dst, format, struct{ x T }{v})
+	}
+	off := 1
+	if plus || sharp {
+		off = bytes.Index(dst[start:], marker) + len(marker)
+	}
+	n := copy(dst[start:], dst[start+off:len(dst)-1])
+	return dst[:start+n]
+}
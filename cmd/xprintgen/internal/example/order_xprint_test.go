@@ -0,0 +1,45 @@
+// Code generated by xprintgen; DO NOT EDIT.
+
+package example
+
+import (
+	"fmt"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func TestXprintgen(t *testing.T) {
+	values := []any{
+		Order{},
+		&Order{},
+		Order{ID: -42, Customer: "a \"b\"\n", Status: StatusShipped, Priority: PriorityHigh, Items: []Item{Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}, Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}}, Tags: []string{"a \"b\"\n", "a \"b\"\n"}, Paid: true, Weight: 1.5, Meta: map[string]int{"b": -42, "a \"b\"\n": -42}, Counts: map[Status]uint{StatusShipped: 42, StatusPending: 42}, Parent: &Order{ID: -42, Customer: "a \"b\"\n", Status: StatusShipped, Priority: PriorityHigh, Items: []Item{Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}, Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}}, Tags: []string{"a \"b\"\n", "a \"b\"\n"}, Paid: true, Weight: 1.5, Meta: map[string]int{"b": -42, "a \"b\"\n": -42}, Counts: map[Status]uint{StatusShipped: 42, StatusPending: 42}, Parent: &Order{ID: -42, Customer: "a \"b\"\n", Status: StatusShipped, Priority: PriorityHigh, Tags: []string{"a \"b\"\n", "a \"b\"\n"}, Paid: true, Weight: 1.5, Meta: map[string]int{"b": -42, "a \"b\"\n": -42}, Counts: map[Status]uint{StatusShipped: 42, StatusPending: 42}, Dims: [3]float64{1.5}, note: "a \"b\"\n", state: StatusShipped}, Ship: Address{Street: "a \"b\"\n", Zip: -42}, Dims: [3]float64{1.5}, note: "a \"b\"\n", state: StatusShipped, lines: []Item{Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}, Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}}, byStatus: map[Status][]Item{StatusShipped: []Item{Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}, Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}}, StatusPending: []Item{Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}, Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}}}}, Ship: Address{Street: "a \"b\"\n", Zip: -42}, Dims: [3]float64{1.5}, note: "a \"b\"\n", state: StatusShipped, lines: []Item{Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}, Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}}, byStatus: map[Status][]Item{StatusShipped: []Item{Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}, Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}}, StatusPending: []Item{Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}, Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5}}}},
+		Item{},
+		&Item{},
+		Item{SKU: "a \"b\"\n", Qty: 42, Price: 1.5},
+		StatusPending,
+		StatusPaid,
+		StatusShipped,
+		Status(99),
+		PriorityLow,
+		PriorityNormal,
+		PriorityHigh,
+		Priority(99),
+	}
+	// fmt is the reference: xprint prints these types only through the
+	// generated methods, and its reflective printer is tested against fmt
+	for _, v := range values {
+		for _, format := range []string{"%v", "%+v", "%#v", "%s", "%d", "%x", "%q"} {
+			if want, got := fmt.Sprintf(format, v), xprint.Sprintf(format, v); got != want {
+				t.Errorf("%s of %T: got %q, want %q", format, v, got, want)
+			}
+		}
+	}
+	for _, v := range []any{(*Order)(nil), (*Item)(nil)} {
+		for _, format := range []string{"%v", "%+v", "%#v"} {
+			if want, got := fmt.Sprintf(format, v), xprint.Sprintf(format, v); got != want {
+				t.Errorf("%s of %T: got %q, want %q", format, v, got, want)
+			}
+		}
+	}
+}
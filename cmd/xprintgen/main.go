@@ -0,0 +1,87 @@
+// Command xprintgen generates formatting methods for struct and enum types,
+// so xprint can print them without reflection.
+//
+// For every struct type it emits AppendVerb and AppendFormat methods, which
+// implement xprint.VerbAppender and xprint.Appender and reproduce the %v, %+v
+// and %#v output of fmt exactly. Fields of basic types, slices, arrays, maps
+// with string or integer keys, pointers and structs are appended directly,
+// without allocating; fields with String, Error or GoString methods allocate
+// what those methods do. Interface, channel, function and complex fields,
+// fmt.Formatter implementations, and verbs other than %v go through fmt. For
+// every integer type with constants it emits a stringer-style String method,
+// unless the type already has one, plus the same append methods.
+//
+// Typical use is a go:generate directive next to the types:
+//
+//	//go:generate go run gopkg.hlmpn.dev/pkg/xprint/cmd/xprintgen -type=Order,Status -test
+//
+// Flags:
+//
+//	-type    comma-separated list of type names; required
+//	-output  output file name; default <first type>_xprint.go
+//	-test    also write a _test.go file checking the generated methods
+//	         against fmt for zero and sample values of every type
+//
+// The test compares with fmt rather than xprint's reflective printer: once
+// the methods exist xprint prints these types only through them, and the
+// reflective printer is itself tested against fmt.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("xprintgen: ")
+
+	typeNames := flag.String("type", "", "comma-separated list of type names; required")
+	output := flag.String("output", "", "output file name; default <first type>_xprint.go")
+	withTest := flag.Bool("test", false, "also generate a test comparing the output with fmt")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: xprintgen -type T[,T...] [-output file] [-test] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+	types := strings.Split(*typeNames, ",")
+	if *output == "" {
+		*output = strings.ToLower(types[0]) + "_xprint.go"
+	}
+	outPath := filepath.Join(dir, *output)
+
+	g, err := newGenerator(dir, outPath, types)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := g.generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(outPath, src, 0o644); err != nil { //nolint:gosec // generated source is world readable
+		log.Fatal(err)
+	}
+	if *withTest {
+		testSrc, err := g.generateTest()
+		if err != nil {
+			log.Fatal(err)
+		}
+		testPath := strings.TrimSuffix(outPath, ".go") + "_test.go"
+		if err := os.WriteFile(testPath, testSrc, 0o644); err != nil { //nolint:gosec // generated source is world readable
+			log.Fatal(err)
+		}
+	}
+}
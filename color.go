@@ -0,0 +1,160 @@
+package xprint
+
+import (
+	"os"
+	"strconv"
+)
+
+// Style is a set of ANSI text attributes and colors. Attributes can be
+// combined freely; at most one foreground and one background color apply.
+type Style uint32
+
+// Text attributes.
+const (
+	Bold Style = 1 << iota
+	Dim
+	Italic
+	Underline
+	Reverse
+)
+
+// Foreground colors, stored in bits 8-15.
+const (
+	Black Style = (iota + 1) << 8
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+	BrightBlack
+	BrightRed
+	BrightGreen
+	BrightYellow
+	BrightBlue
+	BrightMagenta
+	BrightCyan
+	BrightWhite
+)
+
+// Background colors, stored in bits 16-23.
+const (
+	BgBlack Style = (iota + 1) << 16
+	BgRed
+	BgGreen
+	BgYellow
+	BgBlue
+	BgMagenta
+	BgCyan
+	BgWhite
+)
+
+// ColorMode controls whether Styled values emit ANSI sequences.
+type ColorMode uint8
+
+const (
+	// ColorAuto emits colors unless the NO_COLOR environment variable is set
+	// to a non-empty value.
+	ColorAuto ColorMode = iota
+	// ColorAlways always emits colors.
+	ColorAlways
+	// ColorNever never emits colors.
+	ColorNever
+)
+
+const ansiReset = "\x1b[0m"
+
+// noColor records NO_COLOR at startup, see https://no-color.org.
+var noColor = os.Getenv("NO_COLOR") != ""
+
+// Styled is a value printed with an ANSI style. Create it with Color.
+type Styled struct {
+	Value any
+	Style Style
+}
+
+// Color wraps v so it is printed with style s, for example
+//
+//	xprint.Printf("%-8s done", xprint.Color("ok", Bold|Green))
+//
+// The verb and flags apply to v. Width padding is placed outside the escape
+// sequences and the sequences are not counted towards the width, here or in
+// Table and Format. Only top-level arguments are styled.
+func Color(v any, s Style) Styled {
+	return Styled{Value: v, Style: s}
+}
+
+// colors reports whether Styled values emit escape sequences.
+func (p *printer) colors() bool {
+	switch p.colorMode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	return !noColor
+}
+
+// printStyled formats s.Value for the current verb between the escape
+// sequences of s.Style, padding with spaces outside of them.
+func (p *printer) printStyled(s Styled) {
+	p.arg = s.Value
+	if s.Style == 0 || !p.colors() {
+		p.printArg()
+		return
+	}
+	if p.fmt.zero && !p.fmt.minus {
+		// Zero padding belongs to the number itself
+		p.buf = appendStyle(p.buf, s.Style)
+		p.printArg()
+		p.buf.writeString(ansiReset)
+		return
+	}
+	wid, widPresent, minus := p.fmt.wid, p.fmt.widPresent, p.fmt.minus
+	p.fmt.wid, p.fmt.widPresent = 0, false
+	start := len(p.buf)
+	p.buf = appendStyle(p.buf, s.Style)
+	value := len(p.buf)
+	p.printArg()
+	pad := 0
+	if widPresent {
+		pad = wid - bytesWidth(p.buf[value:])
+	}
+	p.buf.writeString(ansiReset)
+	p.fmt.wid, p.fmt.widPresent = wid, widPresent
+	if minus {
+		p.writePadding(pad, ' ')
+	} else {
+		p.padBefore(start, pad, ' ')
+	}
+}
+
+// appendStyle appends the SGR sequence selecting s.
+func appendStyle(dst []byte, s Style) []byte {
+	dst = append(dst, "\x1b["...)
+	n := 0
+	code := func(c int) {
+		if n > 0 {
+			dst = append(dst, ';')
+		}
+		dst = strconv.AppendInt(dst, int64(c), 10)
+		n++
+	}
+	for i, c := range [...]int{1, 2, 3, 4, 7} {
+		if s&(1<<i) != 0 {
+			code(c)
+		}
+	}
+	if fg := int(s>>8) & 0xff; fg > 0 {
+		if fg <= 8 {
+			code(29 + fg)
+		} else {
+			code(81 + fg)
+		}
+	}
+	if bg := int(s>>16) & 0xff; bg > 0 {
+		code(39 + bg)
+	}
+	return append(dst, 'm')
+}
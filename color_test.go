@@ -0,0 +1,35 @@
+package xprint_test
+
+import (
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func TestColor(t *testing.T) {
+	always := xprint.Printer{Color: xprint.ColorAlways}
+	never := xprint.Printer{Color: xprint.ColorNever}
+
+	testCases := []struct {
+		pr       xprint.Printer
+		format   string
+		arg      any
+		expected string
+	}{
+		{always, "%s", xprint.Color("ok", xprint.Green), "\x1b[32mok\x1b[0m"},
+		{always, "%d", xprint.Color(42, xprint.Bold|xprint.BrightRed|xprint.BgBlue), "\x1b[1;91;44m42\x1b[0m"},
+		{always, "[%-4s]", xprint.Color("ok", xprint.Underline), "[\x1b[4mok\x1b[0m  ]"},
+		{always, "[%4s]", xprint.Color("ok", xprint.Red), "[  \x1b[31mok\x1b[0m]"},
+		{always, "%05.1f", xprint.Color(2.5, xprint.Dim), "\x1b[2m002.5\x1b[0m"},
+		{never, "[%4s]", xprint.Color("ok", xprint.Red), "[  ok]"},
+		{always, "%v", xprint.Color("plain", 0), "plain"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if o := tc.pr.Sprintf(tc.format, tc.arg); o != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, o)
+			}
+		})
+	}
+}
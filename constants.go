@@ -4,7 +4,6 @@ const (
 	commaSpaceString  = ", "
 	nilAngleString    = "<nil>"
 	nilParenString    = "(nil)"
-	nilString         = "nil"
 	percentBangString = "%!"
 	missingString     = "(MISSING)"
 	badIndexString    = "(BADINDEX)"
@@ -15,6 +14,7 @@ const (
 	mapString         = "map["
 	panicString       = "(PANIC="
 	extraString       = "%!(EXTRA "
+	redactedString    = "[REDACTED]"
 
 	// invReflectString = "<invalid reflect.Value>"
 )
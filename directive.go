@@ -0,0 +1,121 @@
+package xprint
+
+import "slices"
+
+// directive is one compiled directive of a template: the literal text before
+// it and the flags and verb printf would have parsed from a %-directive.
+type directive struct {
+	lit    string // literal text written before the directive
+	flags  fmtFlags
+	verb   rune
+	hasArg bool // false for the trailing literal of a template
+
+	// Brace templates only
+	index int  // argument index, or -1 for the next argument
+	fill  rune // padding character used with align
+	align byte // '<', '>' or '^'; zero leaves padding to the verb
+}
+
+// parseSpec parses the flags, width, precision and verb of a printf directive
+// without the leading '%', for example "08.3f". An empty spec or one without a
+// verb means %v. It reports false if spec has trailing bytes after the verb.
+func parseSpec(spec string) (fmtFlags, rune, bool) {
+	var f fmtFlags
+	end := len(spec)
+	i := 0
+flags:
+	for ; i < end; i++ {
+		switch spec[i] {
+		case '#':
+			f.sharp = true
+		case '0':
+			f.zero = true
+		case '+':
+			f.plus = true
+		case '-':
+			f.minus = true
+		case ' ':
+			f.space = true
+		case '\'':
+			f.group = true
+		default:
+			break flags
+		}
+	}
+	f.wid, f.widPresent, i = parsenum(spec, i, end)
+	if i < end && spec[i] == '.' {
+		f.prec, f.precPresent, i = parsenum(spec, i+1, end)
+		// "%.f" means precision zero, as in printf
+		f.precPresent = true
+	}
+	verb := 'v'
+	if i < end {
+		verb = rune(spec[i])
+		i++
+	}
+	if verb == 'v' {
+		f.sharpV, f.sharp = f.sharp, false
+		f.plusV, f.plus = f.plus, false
+	}
+	return f, verb, i == end
+}
+
+// printDirective writes the literal of d and formats arg with its flags.
+func (p *printer) printDirective(d *directive, arg any) {
+	p.buf.writeString(d.lit)
+	if !d.hasArg {
+		return
+	}
+	p.fmt.fmtFlags = d.flags
+	p.arg = arg
+	p.verb = d.verb
+	if d.align != 0 && d.flags.widPresent {
+		p.printAligned(d.fill, d.align)
+		return
+	}
+	p.printVerb()
+}
+
+// printAligned formats the current argument without its width, then pads the
+// result to the width with fill, placing it left, right or centered. The width
+// is counted in terminal cells.
+func (p *printer) printAligned(fill rune, align byte) {
+	wid := p.fmt.wid
+	p.fmt.wid, p.fmt.widPresent = 0, false
+	start := len(p.buf)
+	p.printVerb()
+	pad := wid - bytesWidth(p.buf[start:])
+	if pad <= 0 {
+		return
+	}
+	left := 0
+	switch align {
+	case '>':
+		left = pad
+	case '^':
+		left = pad / 2
+	}
+	right := pad - left
+	p.padBefore(start, left, fill)
+	for range right {
+		p.buf.writeRune(fill)
+	}
+}
+
+// padBefore inserts n copies of fill at p.buf[start], shifting the bytes
+// written since start to the right.
+func (p *printer) padBefore(start, n int, fill rune) {
+	if n <= 0 {
+		return
+	}
+	end := len(p.buf)
+	for range n {
+		p.buf.writeRune(fill)
+	}
+	// Rotate the padding in front of the formatted value
+	seg := p.buf[start:]
+	padded := len(p.buf) - end
+	slices.Reverse(seg)
+	slices.Reverse(seg[:padded])
+	slices.Reverse(seg[padded:])
+}
@@ -22,6 +22,14 @@ The package supports all standard formatting directives from the fmt package, in
   - %p - pointer
   - And more
 
+In addition, %j renders any value as compact JSON, honouring json struct tags,
+and the ' flag groups digits, as in %'d.
+
+Sdump, Fdump and Dump render values across multiple indented lines, with field
+names, type annotations, sorted map keys and cycle markers:
+
+	fmt.Print(xprint.Sdump(config))
+
 Performance improvements are most notable when:
   - Formatting strings with the %s verb
   - Working with a mix of string and numeric values
@@ -1,41 +1,104 @@
 package xprint
 
 import (
+	"math/bits"
 	"reflect"
+	"strings"
 )
 
 // doPrintf is the core printf implementation. It formats into p.buf.
 func (p *printer) printf(format string, args []any) {
-	end := len(format)
+	p.presize(estimateSize(format, args))
+	if t := cachedFormat(format); t != nil && len(args) >= t.args {
+		p.printTemplate(t, args, 0, false)
+		return
+	}
 	p.argNum = 0
-	lenOfArgs := len(args)
-	i := 0
-	for i < end {
-		lasti := i
+	p.printfFrom(format, 0, args)
+}
 
-		for i < end && format[i] != '%' {
-			i++
+// estimateSize guesses the length of printf's output: the format itself plus
+// the length of string and []byte operands and the digits of integers. Other
+// operands count as short.
+func estimateSize(format string, args []any) (size, largest int) {
+	size = len(format)
+	for _, arg := range args {
+		var n int
+		switch v := arg.(type) {
+		case string:
+			n = len(v)
+		case []byte:
+			n = len(v)
+		case int:
+			n = intSize(int64(v))
+		case int64:
+			n = intSize(v)
+		case uint:
+			n = uintSize(uint64(v))
+		case uint64:
+			n = uintSize(v)
+		default:
+			n = shortOperandSize
 		}
+		size += n
+		largest = max(largest, n)
+	}
+	return size, largest
+}
 
-		if i > lasti && format[i-1] != '%' {
-			p.buf = append(p.buf, format[lasti:i]...)
+// presize grows p.buf once for the estimated output. Large string results
+// are sized exactly, as the string takes the buffer over, and the other large
+// outputs take a buffer from the pools. Outputs that are mostly a single
+// operand are left alone unless the pools have a buffer for them: appending
+// that operand sizes the buffer exactly, without clearing the memory first
+// like a separate grow does.
+func (p *printer) presize(size, largest int) {
+	switch {
+	case size <= cap(p.buf)-len(p.buf):
+	case p.stringResult && size > printerBufferSize:
+		if size-largest > presizeSlack {
+			p.buf.grow(size)
 		}
+	case size-largest > presizeSlack || bufferPooled(size):
+		p.grow(size)
+	}
+}
 
-		switch end <= i {
-		case true:
+// presizeSlack is how much output besides its largest operand is written
+// without pre-sizing the buffer.
+const presizeSlack = 4 << 10
+
+// shortOperandSize is the estimated length of operands estimateSize doesn't
+// measure.
+const shortOperandSize = 8
+
+// uintSize estimates the number of decimal digits of u.
+func uintSize(u uint64) int {
+	return bits.Len64(u)*1233>>12 + 1
+}
+
+// intSize estimates the length of v in decimal, sign included.
+func intSize(v int64) int {
+	u, negative, _ := splitInt(v)
+	if negative {
+		return uintSize(u) + 1
+	}
+	return uintSize(u)
+}
+
+// printfFrom continues printf at format[i:] with p.argNum arguments used.
+func (p *printer) printfFrom(format string, i int, args []any) {
+	end := len(format)
+	lenOfArgs := len(args)
+	for i < end {
+		p.buf, i, p.argNum = appendPlain(p.buf, format, i, args, p.argNum, p.escape == EscapeNone)
+		if i >= end {
 			break
 		}
 
 		// Process one verb
 		i++
 
-		// Handle %% case
-		if i < end && format[i] == '%' {
-			p.buf = append(p.buf, '%')
-			i++
-			continue
-		}
-
 		p.fmt.clearflags()
 		var c int
 		// Handle flags
@@ -57,13 +120,19 @@ func (p *printer) printf(format string, args []any) {
 				p.fmt.minus = true
 			case current == ' ':
 				p.fmt.space = true
+			case current == '\'':
+				p.fmt.group = true
 			default:
 				goto flags_done
 			}
 			i++
 		}
 	flags_done:
-		if i >= end || p.argNum >= len(args) {
+		if i >= end {
+			p.buf.writeString(noVerbString)
+			break
+		}
+		if p.argNum >= len(args) {
 			p.buf.writeString(percentBangString)
 			p.buf.writeRune(rune(format[i]))
 			p.buf.writeString(missingString)
@@ -117,7 +186,6 @@ func (p *printer) printf(format string, args []any) {
 			}
 		}
 
-		var lastIteration bool
 		if i >= end {
 			p.buf.writeString(noVerbString)
 			break
@@ -125,9 +193,11 @@ func (p *printer) printf(format string, args []any) {
 
 		p.verb = rune(format[i])
 		i++
-
-		if i == end {
-			lastIteration = true
+		if p.verb == 'v' {
+			// Flags before a width still select Go syntax and field names
+			p.fmt.sharpV = p.fmt.sharpV || p.fmt.sharp
+			p.fmt.plusV = p.fmt.plusV || p.fmt.plus
+			p.fmt.sharp, p.fmt.plus = false, false
 		}
 
 		// Handle argument
@@ -138,73 +208,112 @@ func (p *printer) printf(format string, args []any) {
 
 		p.argNum++
 
-		// switch {
-		// case p.arg == nil:
+		if p.escape != EscapeNone {
+			start := len(p.buf)
+			p.printVerb()
+			p.escapeOperand(start)
+			continue
+		}
+		p.printVerb()
+	}
+}
 
-		// 	p.buf.writeNilArg(p.verb)
-		// 	continue
-		// }
+// printCharArg formats p.arg for %c or %U. Only integers are characters;
+// other operands keep the no-verb error.
+func (p *printer) printCharArg() {
+	if k := reflect.ValueOf(p.arg).Kind(); k >= reflect.Int && k <= reflect.Uintptr {
+		p.printArg()
+		return
+	}
+	p.buf.writeString(percentBangString)
+	p.buf.writeRune(p.verb)
+	p.buf.writeString(noVerbString)
+}
 
-		if p.ArgIsString() && p.verb == 's' && p.verb != 'T' && !p.fmt.widPresent {
-			// Fast path: string value with no width formatting, use direct concatenation
-			p.buf = append(p.buf, p.arg.(string)...) //nolint:forcetypeassert //
-			continue
-		} else if p.ArgIsBytes() && p.verb == 's' && p.verb != 'T' && !p.fmt.widPresent {
-			// Fast path: byte slice value with no width formatting, use direct concatenation
-			p.buf = append(p.buf, p.arg.([]byte)...) //nolint:forcetypeassert //
-			continue
+// appendPlain appends the literal text of format[i:] to dst together with
+// the %% directives and, if strs is set, the %s directives of string and
+// []byte arguments, none of which need the printer. It stops at the '%' of
+// the first other directive and returns dst, the index of that '%' or
+// len(format), and the next argument number.
+func appendPlain(dst []byte, format string, i int, args []any, argNum int, strs bool) ([]byte, int, int) {
+	for {
+		// Copy the literal text up to the next '%' in bulk
+		next := strings.IndexByte(format[i:], '%')
+		if next < 0 {
+			return append(dst, format[i:]...), len(format), argNum
 		}
-		p.fmt.uintbase = 10
-		p.fmt.toupper = false
-		switch p.verb {
-		case 'v':
-			p.printArg()
-		case 'o':
-			p.fmt.uintbase = 8
-			p.printArg()
-		case 'O':
-			p.fmt.uintbase = 8
-			p.fmt.toupper = true
-		case 'd':
-			p.printArg()
-		case 'x':
-			p.fmt.uintbase = 16
-			p.printArg()
-		case 'X':
-			p.fmt.uintbase = 16
-			p.fmt.toupper = true
-			p.printArg()
-		case 'b':
-			p.fmt.uintbase = 2
-			p.printArg()
-		case 'B':
-			p.fmt.uintbase = 2
-			p.fmt.toupper = true
-			p.printArg()
-		case 'f', 'F', 'g', 'G', 'e', 'E':
-			p.printArg()
-		case 's': // 's'
-			p.printArg()
-		case 'q':
-			// use switch even tho single case for more oprimal type conv
-			switch v := p.arg.(type) { //nolint:all //
-			case string:
-				p.arg = `"` + v + `"`
-			}
-			p.printArg()
-		case 't':
-			p.printBool(p.arg)
-		case 'T':
-			p.printReflectType(p.arg)
-		case 'p':
-			p.fmtPointer(reflect.ValueOf(p.arg), p.verb)
-		default:
-			p.buf.writeString(percentBangString)
-			p.buf.writeRune(p.verb)
-			p.buf.writeString(noVerbString)
-			if lastIteration {
-				break
+		dst = append(dst, format[i:i+next]...)
+		i += next
+		if i+1 < len(format) {
+			switch format[i+1] {
+			case '%':
+				dst = append(dst, '%')
+				i += 2
+				continue
+			case 's':
+				if !strs || argNum >= len(args) {
+					break
+				}
+				switch v := args[argNum].(type) {
+				case string:
+					dst = append(dst, v...)
+				case []byte:
+					dst = append(dst, v...)
+				default:
+					return dst, i, argNum
+				}
+				i += 2
+				argNum++
+				continue
 			}
 		}
+		return dst, i, argNum
+	}
+}
+
+// printVerb formats p.arg for p.verb using the flags already set in p.fmt.
+func (p *printer) printVerb() {
+	if p.ArgIsString() && p.verb == 's' && p.verb != 'T' && !p.fmt.widPresent {
+		// Fast path: string value with no width formatting, use direct concatenation
+		p.buf = append(p.buf, p.arg.(string)...) //nolint:forcetypeassert //
+		return
+	} else if p.ArgIsBytes() && p.verb == 's' && p.verb != 'T' && !p.fmt.widPresent {
+		// Fast path: byte slice value with no width formatting, use direct concatenation
+		p.buf = append(p.buf, p.arg.([]byte)...) //nolint:forcetypeassert //
+		return
+	}
+	// Registered verbs are consulted before the built-in ones
+	if fn := lookupVerb(p.verb); fn != nil {
+		p.printCustomVerb(fn)
+		return
+	}
+	switch p.verb {
+	case 'v', 'd', 'o', 'O', 'x', 'X', 'b', 'B':
+		p.printArg()
+	case 'c', 'U':
+		p.printCharArg()
+	case 'f', 'F', 'g', 'G', 'e', 'E':
+		p.printArg()
+	case 's': // 's'
+		p.printArg()
+	case 'q':
+		// use switch even tho single case for more oprimal type conv
+		switch v := p.arg.(type) { //nolint:all //
+		case string:
+			p.arg = `"` + v + `"`
+		}
+		p.printArg()
+	case 't':
+		p.printBool(p.arg)
+	case 'T':
+		p.printReflectType(p.arg)
+	case 'p':
+		p.fmtPointer(reflect.ValueOf(p.arg), p.verb)
+	case 'j':
+		p.printJSON(p.arg)
+	default:
+		p.buf.writeString(percentBangString)
+		p.buf.writeRune(p.verb)
+		p.buf.writeString(noVerbString)
 	}
 }
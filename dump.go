@@ -0,0 +1,237 @@
+package xprint
+
+import (
+	"cmp"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	reflect "github.com/goccy/go-reflect"
+)
+
+// dumpIndent is the indentation added for every nesting level in dump output.
+const dumpIndent = "  "
+
+// Sdump returns a multi-line, indented rendering of each value, one value per
+// line. Nested structs, slices, arrays, maps and pointers are expanded with
+// their field names and type annotations, map keys are sorted and reference
+// cycles are replaced by a (CYCLIC REFERENCE) marker.
+func Sdump(values ...any) string {
+	p := newPrinter()
+	p.dump(values)
+	return p.takeString()
+}
+
+// Fdump writes the Sdump rendering of each value to w.
+func Fdump(w io.Writer, values ...any) (int, error) {
+	p := newPrinter()
+	p.dump(values)
+	n, err := w.Write(p.buf)
+	p.free()
+	if err != nil {
+		return n, errors.New("xprint: " + err.Error())
+	}
+	return n, nil
+}
+
+// Dump writes the Sdump rendering of each value to standard output.
+func Dump(values ...any) {
+	Fdump(os.Stdout, values...) //nolint:errcheck // like fmt.Println
+}
+
+// dump renders each value in pretty mode followed by a newline.
+func (p *printer) dump(values []any) {
+	p.fmt.pretty = true
+	for _, v := range values {
+		p.depth = 0
+		p.arg = v
+		p.verb = 'v'
+		if v == nil {
+			p.buf.writeString(nilAngleString)
+		} else {
+			p.printValue(reflect.ValueOf(v), 'v', 0)
+		}
+		p.buf.writeByte('\n')
+	}
+	p.fmt.pretty = false
+}
+
+// newline starts a new line indented to the current depth.
+func (p *printer) newline() {
+	p.buf.writeByte('\n')
+	for range p.depth {
+		p.buf.writeString(dumpIndent)
+	}
+}
+
+// printPretty renders composite kinds, strings and named scalar types across
+// multiple lines for dump output. Elements are formatted by printValue, so
+// every type printValue understands can be nested. It reports false for the
+// kinds it leaves to printValue's regular switch.
+func (p *printer) printPretty(v reflect.Value, verb rune, prec int) bool {
+	switch v.Kind() {
+	case reflect.Struct:
+		p.buf.writeString(v.Type().String())
+		p.buf.writeByte('{')
+		fields := fieldsOf(v.Type())
+		p.depth++
+		empty := true
+		for i := range v.NumField() {
+			action := fields.action(i)
+			if action == fieldOmit {
+				continue
+			}
+			empty = false
+			p.newline()
+			p.buf.writeString(v.Type().Field(i).Name)
+			p.buf.writeString(": ")
+			if action == fieldRedact {
+				p.buf.writeString(redactedString)
+			} else {
+				p.printValue(v.Field(i), verb, prec)
+			}
+			p.buf.writeByte(',')
+		}
+		p.depth--
+		if !empty {
+			p.newline()
+		}
+		p.buf.writeByte('}')
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			p.buf.writeString(v.Type().String())
+			p.buf.writeString(nilParenString)
+			return true
+		}
+		p.buf.writeString(v.Type().String())
+		p.buf.writeByte('{')
+		if v.Len() == 0 {
+			p.buf.writeByte('}')
+			return true
+		}
+		p.depth++
+		for i := range v.Len() {
+			p.newline()
+			p.printValue(v.Index(i), verb, prec)
+			p.buf.writeByte(',')
+		}
+		p.depth--
+		p.newline()
+		p.buf.writeByte('}')
+	case reflect.Map:
+		if v.IsNil() {
+			p.buf.writeString(v.Type().String())
+			p.buf.writeString(nilParenString)
+			return true
+		}
+		ptr := v.Pointer()
+		if p.visitedPtrs.visit(ptr) {
+			p.buf.writeString(v.Type().String())
+			p.buf.writeString("(CYCLIC REFERENCE)")
+			return true
+		}
+		defer p.visitedPtrs.leave(ptr)
+		p.buf.writeString(v.Type().String())
+		p.buf.writeByte('{')
+		keys := sortedMapKeys(v)
+		if len(keys) == 0 {
+			p.buf.writeByte('}')
+			return true
+		}
+		p.depth++
+		for _, key := range keys {
+			p.newline()
+			p.printValue(key, verb, prec)
+			p.buf.writeString(": ")
+			p.printValue(v.MapIndex(key), verb, prec)
+			p.buf.writeByte(',')
+		}
+		p.depth--
+		p.newline()
+		p.buf.writeByte('}')
+	case reflect.Ptr:
+		if v.IsNil() {
+			p.buf.writeByte('(')
+			p.buf.writeString(v.Type().String())
+			p.buf.writeString(")(nil)")
+			return true
+		}
+		// Only pointers on the current path are cycles; shared pointers
+		// elsewhere in the tree are expanded each time they appear.
+		ptr := v.Pointer()
+		if p.visitedPtrs.visit(ptr) {
+			p.buf.writeByte('&')
+			p.buf.writeString(v.Type().Elem().String())
+			p.buf.writeString("(CYCLIC REFERENCE)")
+			return true
+		}
+		defer p.visitedPtrs.leave(ptr)
+		p.buf.writeByte('&')
+		p.printValue(v.Elem(), verb, prec)
+	case reflect.Interface:
+		if v.IsNil() {
+			p.buf.writeString(v.Type().String())
+			p.buf.writeString(nilParenString)
+			return true
+		}
+		p.printValue(v.Elem(), verb, prec)
+	case reflect.String:
+		named := v.Type().Name() != "string"
+		if named {
+			p.buf.writeString(v.Type().String())
+			p.buf.writeByte('(')
+		}
+		p.buf = strconv.AppendQuote(p.buf, v.String())
+		if named {
+			p.buf.writeByte(')')
+		}
+	default:
+		// Named scalar types get a type annotation around the plain value.
+		t := v.Type()
+		if t.PkgPath() == "" || t.Name() == "" {
+			return false
+		}
+		p.buf.writeString(t.String())
+		p.buf.writeByte('(')
+		p.fmt.pretty = false
+		p.printValue(v, verb, prec)
+		p.fmt.pretty = true
+		p.buf.writeByte(')')
+	}
+	return true
+}
+
+// sortedMapKeys returns the keys of the map v in a stable order: numbers,
+// strings and bools are compared by value, anything else keeps map order.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	slices.SortStableFunc(keys, compareMapKeys)
+	return keys
+}
+
+func compareMapKeys(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case !a.Bool():
+			return -1
+		default:
+			return 1
+		}
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,63 @@
+package xprint_test
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+type dumpLevel int
+
+type dumpNode struct {
+	Name  string
+	Level dumpLevel
+	Tags  []string
+	Attrs map[string]int
+	Next  *dumpNode
+}
+
+func TestSdump(t *testing.T) {
+	n := &dumpNode{
+		Name:  "root",
+		Level: 2,
+		Tags:  []string{"a", "b"},
+		Attrs: map[string]int{"z": 1, "a": 2},
+	}
+	n.Next = n
+
+	expected := `&xprint_test.dumpNode{
+  Name: "root",
+  Level: xprint_test.dumpLevel(2),
+  Tags: []string{
+    "a",
+    "b",
+  },
+  Attrs: map[string]int{
+    "a": 2,
+    "z": 1,
+  },
+  Next: &xprint_test.dumpNode(CYCLIC REFERENCE),
+}
+`
+	if o := xprint.Sdump(n); o != expected {
+		t.Errorf("Expected %s, got %s", expected, o)
+	}
+}
+
+func TestSdumpSharedPointer(t *testing.T) {
+	shared := &dumpNode{Name: "shared"}
+	pair := []*dumpNode{shared, shared}
+	o := xprint.Sdump(pair)
+	if want := `Name: "shared"`; strings.Count(o, want) != 2 {
+		t.Errorf("Expected shared pointer to be expanded twice, got %s", o)
+	}
+}
+
+func TestSdumpScalars(t *testing.T) {
+	o := xprint.Sdump(42, "text", nil, []int(nil))
+	expected := "42\n\"text\"\n<nil>\n[]int(nil)\n"
+	if o != expected {
+		t.Errorf("Expected %q, got %q", expected, o)
+	}
+}
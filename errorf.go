@@ -52,13 +52,23 @@ func Errorf(format string, a ...any) error {
 		}
 	}
 
-	// Find %w verbs and replace them with %v, while tracking positions
-	wrappedErrs := make([]int, 0, 1)
+	var s string
+	var wrappedErrs []int
 	reordered := false
-	modifiedFormat := parseErrorFormat(format, &wrappedErrs, &reordered)
-
-	// Format the message using the modified format
-	s := Printf(modifiedFormat, a...)
+	if t := cachedFormat(format); t != nil && len(a) >= t.args {
+		// Cached formats know where their %w verbs are
+		p := newPrinter()
+		p.printTemplate(t, a, 0, true)
+		s = p.takeString()
+		wrappedErrs = t.wrapped
+	} else {
+		// Find %w verbs and replace them with %v, while tracking positions
+		wrappedErrs = make([]int, 0, 1)
+		modifiedFormat := parseErrorFormat(format, &wrappedErrs, &reordered)
+
+		// Format the message using the modified format
+		s = Printf(modifiedFormat, a...)
+	}
 
 	// Create appropriate error type
 	var err error
@@ -148,7 +158,7 @@ func parseErrorFormat(format string, wrappedErrs *[]int, reordered *bool) string
 		// Copy any flags, width, precision
 		for i < len(format) {
 			// Check for flags
-			if strings.ContainsRune("+-0# ", rune(format[i])) {
+			if strings.ContainsRune("+-0# '", rune(format[i])) {
 				formatVerb.WriteByte(format[i])
 				i++
 				continue
@@ -0,0 +1,206 @@
+package xprint
+
+import (
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// EscapeMode selects how a Printer escapes the output of every operand,
+// whatever its verb. Literal text in the format string is never escaped.
+type EscapeMode uint8
+
+const (
+	// EscapeNone writes operands unchanged.
+	EscapeNone EscapeMode = iota
+	// EscapeHTML escapes operands for HTML text and attribute values.
+	EscapeHTML
+	// EscapeJSON escapes operands for the contents of a JSON string. The
+	// surrounding quotes belong in the format string.
+	EscapeJSON
+	// EscapeShell single-quotes operands for POSIX shells.
+	EscapeShell
+	// EscapeSQL writes operands as SQL string literals. Single quotes and
+	// backslashes are doubled, so the literal cannot end early under MySQL's
+	// backslash escapes; databases that keep backslashes literal, such as
+	// PostgreSQL with standard_conforming_strings, store them twice. Booleans
+	// and numbers without methods are written bare and nil as NULL.
+	EscapeSQL
+)
+
+const hexDigits = "0123456789abcdef"
+
+// escapeOperand rewrites the operand written at p.buf[start:] according to
+// p.escape.
+func (p *printer) escapeOperand(start int) {
+	seg := p.buf[start:]
+	switch p.escape {
+	case EscapeHTML:
+		if !containsAny(seg, `&<>"'`) {
+			return
+		}
+	case EscapeJSON:
+		if !needsJSONEscape(seg) {
+			return
+		}
+	case EscapeSQL:
+		if p.arg == nil {
+			p.buf = append(p.buf[:start], "NULL"...)
+			return
+		}
+		if sqlBare(p.arg, p.verb) {
+			return
+		}
+	case EscapeNone:
+		return
+	}
+
+	var scratch [128]byte
+	src := append(scratch[:0], seg...)
+	p.buf = p.buf[:start]
+	switch p.escape {
+	case EscapeHTML:
+		p.buf = appendHTMLEscaped(p.buf, src)
+	case EscapeJSON:
+		p.buf = appendJSONEscaped(p.buf, src)
+	case EscapeShell:
+		p.buf = appendQuoted(p.buf, src, `'\''`)
+	case EscapeSQL:
+		p.buf = appendSQLQuoted(p.buf, src)
+	}
+}
+
+// sqlBare reports whether arg, printed with verb, needs no SQL quoting: a
+// boolean or number without methods, whose output cannot contain a quote.
+func sqlBare(arg any, verb rune) bool {
+	if verb == 'c' || verb == 'q' {
+		return false
+	}
+	t := reflect.TypeOf(arg)
+	if t.NumMethod() != 0 {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+func containsAny(b []byte, chars string) bool {
+	for _, c := range b {
+		if strings.IndexByte(chars, c) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// appendHTMLEscaped escapes like html.EscapeString.
+func appendHTMLEscaped(dst, src []byte) []byte {
+	for _, c := range src {
+		switch c {
+		case '&':
+			dst = append(dst, "&amp;"...)
+		case '<':
+			dst = append(dst, "&lt;"...)
+		case '>':
+			dst = append(dst, "&gt;"...)
+		case '"':
+			dst = append(dst, "&#34;"...)
+		case '\'':
+			dst = append(dst, "&#39;"...)
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+func needsJSONEscape(b []byte) bool {
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if c < 0x20 || c == '"' || c == '\\' {
+			return true
+		}
+		// U+2028 and U+2029 are valid JSON but break JavaScript
+		if c == 0xe2 && i+2 < len(b) && b[i+1] == 0x80 && (b[i+2] == 0xa8 || b[i+2] == 0xa9) {
+			return true
+		}
+		if c >= utf8.RuneSelf {
+			if r, size := utf8.DecodeRune(b[i:]); r == utf8.RuneError && size == 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// appendJSONEscaped escapes src for a JSON string, replacing invalid UTF-8
+// with U+FFFD as encoding/json does.
+func appendJSONEscaped[S ~string | ~[]byte](dst []byte, src S) []byte {
+	for i := 0; i < len(src); {
+		c := src[i]
+		if c < utf8.RuneSelf {
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				if c < 0x20 {
+					dst = append(dst, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+				} else {
+					dst = append(dst, c)
+				}
+			}
+			i++
+			continue
+		}
+		// At most utf8.UTFMax bytes are converted, which needs no allocation
+		r, size := utf8.DecodeRuneInString(string(src[i:min(i+utf8.UTFMax, len(src))]))
+		switch {
+		case r == utf8.RuneError && size == 1:
+			dst = append(dst, "\ufffd"...)
+		case r == '\u2028' || r == '\u2029':
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+		default:
+			dst = append(dst, src[i:i+size]...)
+		}
+		i += size
+	}
+	return dst
+}
+
+// appendQuoted wraps src in single quotes, replacing each single quote in it
+// with quote.
+func appendQuoted(dst, src []byte, quote string) []byte {
+	dst = append(dst, '\'')
+	for _, c := range src {
+		if c == '\'' {
+			dst = append(dst, quote...)
+		} else {
+			dst = append(dst, c)
+		}
+	}
+	return append(dst, '\'')
+}
+
+// appendSQLQuoted wraps src in single quotes, doubling single quotes and
+// backslashes in it.
+func appendSQLQuoted(dst, src []byte) []byte {
+	dst = append(dst, '\'')
+	for _, c := range src {
+		if c == '\'' || c == '\\' {
+			dst = append(dst, c)
+		}
+		dst = append(dst, c)
+	}
+	return append(dst, '\'')
+}
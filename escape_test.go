@@ -0,0 +1,46 @@
+package xprint_test
+
+import (
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func TestEscapeModes(t *testing.T) {
+	testCases := []struct {
+		mode     xprint.EscapeMode
+		format   string
+		args     []any
+		expected string
+	}{
+		{xprint.EscapeHTML, "<b>%s</b>", []any{`Tom & "Jerry" <3`}, "<b>Tom &amp; &#34;Jerry&#34; &lt;3</b>"},
+		{xprint.EscapeHTML, "<i>%v</i> %d", []any{"plain", 5}, "<i>plain</i> 5"},
+		{xprint.EscapeJSON, `{"msg":"%s"}`, []any{"line\n\"quoted\"\t\\ \x01"}, `{"msg":"line\n\"quoted\"\t\\ \u0001"}`},
+		{xprint.EscapeJSON, `"%s"`, []any{"a\u2028b\xff"}, `"a\u2028b` + "\ufffd" + `"`},
+		{xprint.EscapeShell, "rm -rf %s", []any{"it's here"}, `rm -rf 'it'\''s here'`},
+		{xprint.EscapeSQL, "SELECT * FROM t WHERE name = %s AND id = %d", []any{"O'Brien", 7}, "SELECT * FROM t WHERE name = 'O''Brien' AND id = 7"},
+		{xprint.EscapeNone, "<%s>", []any{"&"}, "<&>"},
+		{xprint.EscapeHTML, "%d|%x|%10s|%T|%j", []any{"<a>", "<b>", "<c>", "<d>", "<e>"}, "&lt;a&gt;|&lt;b&gt;|       &lt;c&gt;|string|&#34;&lt;e&gt;&#34;"},
+		{xprint.EscapeHTML, "%c %U %x", []any{'<', '>', []string{"<"}}, "&lt; U+003E [&lt;]"},
+		{xprint.EscapeJSON, `"%d %j"`, []any{`"`, `a"b`}, `"\" \"a\\\"b\""`},
+		{xprint.EscapeShell, "echo %d %T", []any{7, "x"}, "echo '7' 'string'"},
+		{xprint.EscapeSQL, "WHERE name = %s", []any{`x\' OR 1=1 --`}, `WHERE name = 'x\\'' OR 1=1 --'`},
+		{xprint.EscapeSQL, "VALUES (%v, %.1f, %t, %s, %d)", []any{-7, 2.5, true, nil, uint8(3)}, "VALUES (-7, 2.5, true, NULL, 3)"},
+		{xprint.EscapeSQL, "VALUES (%c, %q, %v, %x)", []any{39, 39, level(1), "'"}, `VALUES ('''', '''\\''''', 'debug', '''')`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			pr := xprint.Printer{Escape: tc.mode}
+			if o := pr.Sprintf(tc.format, tc.args...); o != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, o)
+			}
+		})
+	}
+}
+
+type level int
+
+func (l level) String() string {
+	return [...]string{"info", "debug"}[l]
+}
@@ -5,9 +5,9 @@ type fmtFlags struct {
 	widPresent, precPresent         bool
 	minus, plus, sharp, space, zero bool
 	plusV, sharpV                   bool
+	pretty                          bool
+	group                           bool // ' flag: group integer digits
 	wid, prec                       int
-	uintbase                        int
-	toupper                         bool
 }
 
 // fmt holds the formatting state
@@ -16,6 +16,7 @@ type fmt struct {
 	fmtFlags
 	// intbuf is large enough to store %b of an int64 with a sign and
 	// avoids padding at the end of the struct on 32 bit architectures.
+	intbuf [68]byte
 }
 
 func (f *fmt) init(b *buffer) {
@@ -33,6 +34,8 @@ func (f *fmt) clearflags() {
 	f.zero = false
 	f.plusV = false
 	f.sharpV = false
+	f.pretty = false
+	f.group = false
 	f.wid = 0
 	f.prec = 0
 }
@@ -42,14 +45,24 @@ type visited struct {
 	ptrs map[uintptr]bool
 }
 
+// init resets the tracker. The map is only allocated once a pointer is
+// visited, so printing values without pointers does not allocate.
 func (v *visited) init() {
-	v.ptrs = make(map[uintptr]bool)
+	clear(v.ptrs)
 }
 
 func (v *visited) visit(p uintptr) bool {
 	if v.ptrs[p] {
 		return true
 	}
+	if v.ptrs == nil {
+		v.ptrs = make(map[uintptr]bool)
+	}
 	v.ptrs[p] = true
 	return false
 }
+
+// leave forgets p again, so only pointers on the current path are tracked.
+func (v *visited) leave(p uintptr) {
+	delete(v.ptrs, p)
+}
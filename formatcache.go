@@ -0,0 +1,264 @@
+package xprint
+
+import (
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultFormatCacheSize is the number of format strings cached until
+// SetFormatCacheSize is called.
+const defaultFormatCacheSize = 1024
+
+// formatTemplate is a printf format string parsed into directives. The last
+// directive holds the trailing literal and takes no argument.
+type formatTemplate struct {
+	directives []directive
+	args       int   // operands consumed, one per directive with an argument
+	wrapped    []int // operand indexes of %w, used by Errorf
+	ok         bool  // false if the format needs printf's own error handling
+}
+
+// formatCache holds parsed format strings keyed by their source. Lookups in
+// templates take no lock. New formats collect in pending under mu and are
+// merged into a new templates map once pending outgrows it, so inserts copy
+// each entry a bounded number of times.
+var formatCache struct {
+	enabled      atomic.Bool
+	hits, misses atomic.Uint64
+	limit        atomic.Int64
+	entries      atomic.Int64 // formats in templates and pending
+	templates    atomic.Pointer[map[string]*formatTemplate]
+
+	mu          sync.Mutex
+	pending     map[string]*formatTemplate
+	pendingHits int // lookups answered from pending since the last merge
+}
+
+func init() {
+	formatCache.limit.Store(defaultFormatCacheSize)
+}
+
+// CacheStats reports the state of the format cache.
+type CacheStats struct {
+	Hits    uint64 // lookups answered by the cache
+	Misses  uint64 // lookups that had to parse the format
+	Entries int    // formats currently cached
+	Limit   int    // maximum number of cached formats
+}
+
+// CacheFormats controls whether Printf, Sprintf, Fprintf, Appendf, Errorf and
+// the Printer methods keep parsed format strings in a bounded cache, so
+// constant formats are parsed once. It is off by default. Output is the same
+// either way.
+func CacheFormats(enabled bool) {
+	formatCache.enabled.Store(enabled)
+}
+
+// SetFormatCacheSize empties the format cache, resets its statistics and
+// limits it to n formats. Once full, further formats are parsed on every call.
+// A size of zero or less caches nothing.
+func SetFormatCacheSize(n int) {
+	formatCache.mu.Lock()
+	defer formatCache.mu.Unlock()
+	formatCache.limit.Store(int64(max(n, 0)))
+	formatCache.templates.Store(nil)
+	formatCache.pending, formatCache.pendingHits = nil, 0
+	formatCache.entries.Store(0)
+	formatCache.hits.Store(0)
+	formatCache.misses.Store(0)
+}
+
+// FormatCacheStats returns the hit and miss counts and the size of the
+// format cache.
+func FormatCacheStats() CacheStats {
+	return CacheStats{
+		Hits:    formatCache.hits.Load(),
+		Misses:  formatCache.misses.Load(),
+		Entries: int(formatCache.entries.Load()),
+		Limit:   int(formatCache.limit.Load()),
+	}
+}
+
+// cachedFormat returns the parsed form of format, or nil if caching is off,
+// the cache is full or format has to go through printf.
+func cachedFormat(format string) *formatTemplate {
+	if !formatCache.enabled.Load() {
+		return nil
+	}
+	if m := formatCache.templates.Load(); m != nil {
+		if t, ok := (*m)[format]; ok {
+			formatCache.hits.Add(1)
+			return t.usable()
+		}
+	}
+	// A full cache has nothing pending and stays full until
+	// SetFormatCacheSize, so its misses need not wait for the lock
+	if formatCache.entries.Load() >= formatCache.limit.Load() {
+		formatCache.misses.Add(1)
+		return nil
+	}
+
+	formatCache.mu.Lock()
+	defer formatCache.mu.Unlock()
+	var m map[string]*formatTemplate
+	if cur := formatCache.templates.Load(); cur != nil {
+		m = *cur
+	}
+	if t, ok := m[format]; ok {
+		formatCache.hits.Add(1)
+		return t.usable()
+	}
+	if t, ok := formatCache.pending[format]; ok {
+		formatCache.hits.Add(1)
+		// Merge once the locked lookups have paid for the copy
+		formatCache.pendingHits++
+		if formatCache.pendingHits >= len(m)+len(formatCache.pending) {
+			mergePending(m)
+		}
+		return t.usable()
+	}
+	formatCache.misses.Add(1)
+	if formatCache.entries.Load() >= formatCache.limit.Load() {
+		return nil
+	}
+	t := compileFormat(format)
+	if formatCache.pending == nil {
+		formatCache.pending = make(map[string]*formatTemplate)
+	}
+	formatCache.pending[format] = t
+	if formatCache.entries.Add(1) >= formatCache.limit.Load() || len(formatCache.pending) > len(m) {
+		mergePending(m)
+	}
+	return t.usable()
+}
+
+// mergePending publishes the pending formats in a copy of m, the current
+// templates. formatCache.mu must be held.
+func mergePending(m map[string]*formatTemplate) {
+	next := make(map[string]*formatTemplate, len(m)+len(formatCache.pending))
+	maps.Copy(next, m)
+	maps.Copy(next, formatCache.pending)
+	formatCache.templates.Store(&next)
+	formatCache.pending, formatCache.pendingHits = nil, 0
+}
+
+func (t *formatTemplate) usable() *formatTemplate {
+	if !t.ok {
+		return nil
+	}
+	return t
+}
+
+// compileFormat parses format the way printf does. Formats printf reports
+// errors for, or that take widths and precisions from the arguments, are
+// returned with ok unset and keep going through printf.
+func compileFormat(format string) *formatTemplate {
+	t := &formatTemplate{}
+	var lit []byte
+	end := len(format)
+	for i := 0; ; {
+		next := strings.IndexByte(format[i:], '%')
+		if next < 0 {
+			lit = append(lit, format[i:]...)
+			break
+		}
+		lit = append(lit, format[i:i+next]...)
+		i += next + 1
+		if i < end && format[i] == '%' {
+			lit = append(lit, '%')
+			i++
+			continue
+		}
+
+		d := directive{lit: string(lit), hasArg: true}
+		lit = lit[:0]
+		f := &d.flags
+	flags:
+		for ; i < end; i++ {
+			switch format[i] {
+			case '#':
+				f.sharp = true
+			case '0':
+				f.zero = true
+			case '+':
+				f.plus = true
+			case '-':
+				f.minus = true
+			case ' ':
+				f.space = true
+			case '\'':
+				f.group = true
+			default:
+				break flags
+			}
+		}
+		if i >= end || format[i] == '*' {
+			return t
+		}
+		f.wid, f.widPresent, i = parsenum(format, i, end)
+		if i < end && format[i] == '.' {
+			if i++; i < end && format[i] == '*' {
+				return t
+			}
+			f.prec, f.precPresent, i = parsenum(format, i, end)
+		}
+		// Argument indexes are only understood by Errorf
+		if i >= end || format[i] == '[' {
+			return t
+		}
+		d.verb = rune(format[i])
+		i++
+		switch d.verb {
+		case 'v':
+			f.sharpV, f.sharp = f.sharp, false
+			f.plusV, f.plus = f.plus, false
+		case 'w':
+			t.wrapped = append(t.wrapped, t.args)
+		}
+		t.directives = append(t.directives, d)
+		t.args++
+	}
+	t.directives = append(t.directives, directive{lit: string(lit)})
+	t.ok = true
+	return t
+}
+
+// printTemplate formats args according to t, starting at directive from; t
+// must not take more arguments than given. If wrap is set %w is formatted as
+// %v, as in Errorf.
+func (p *printer) printTemplate(t *formatTemplate, args []any, from int, wrap bool) {
+	last := len(t.directives) - 1
+	for i := from; i < last; i++ {
+		d := &t.directives[i]
+		p.buf.writeString(d.lit)
+		arg := args[i]
+		if d.verb == 's' && d.flags == (fmtFlags{}) && p.escape == EscapeNone {
+			switch v := arg.(type) {
+			case string:
+				p.buf.writeString(v)
+				continue
+			case []byte:
+				p.buf.write(v)
+				continue
+			}
+		}
+		p.fmt.fmtFlags = d.flags
+		p.arg, p.verb = arg, d.verb
+		if wrap && d.verb == 'w' {
+			p.verb = 'v'
+			p.fmt.sharpV, p.fmt.sharp = p.fmt.sharp, false
+			p.fmt.plusV, p.fmt.plus = p.fmt.plus, false
+		}
+		if p.escape != EscapeNone {
+			start := len(p.buf)
+			p.printVerb()
+			p.escapeOperand(start)
+			continue
+		}
+		p.printVerb()
+	}
+	p.buf.writeString(t.directives[last].lit)
+	p.argNum = t.args
+}
@@ -0,0 +1,135 @@
+package xprint_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// TestFormatCache checks that cached formats print exactly what parsing them
+// every time does, including formats the cache hands back to printf.
+func TestFormatCache(t *testing.T) {
+	testCases := []struct {
+		format string
+		args   []any
+	}{
+		{"%s=%s;", []any{"key", []byte("value")}},
+		{"%d items at %.2f each, %5.1f%% off", []any{3, 9.5, 12.25}},
+		{"%#v %+v %#x %-6d|", []any{struct{ A int }{1}, struct{ B string }{"b"}, 255, -4}},
+		{"%08.3f %+d % d %'d", []any{3.14159, 5, 7, 1234567}},
+		{"%q %x %X %c %U", []any{"quote", "hex", []byte{1, 171}, 'x', 0x1F600}},
+		{"%*d|%-*s|%.*f", []any{5, 42, 4, "ab", 2, 3.14159}},
+		{"%s %s %s", []any{"only", "two"}},
+		{"%s %d", []any{"extra", 1, 2}},
+		{"%.f %.d %5.s|", []any{2.5, 7, "abc"}},
+		{"no directives", []any{1}},
+		{"trailing %", []any{1}},
+		{"%z %!", []any{1, 2}},
+		// Longer than Printf's scratch buffer, with literal text first
+		{"hello %d " + strings.Repeat("x", 200), []any{1}},
+		{"id=%s, n=%d: %s", []any{"a", 2, strings.Repeat("y", 300)}},
+		{strings.Repeat("z", 150) + " %s %%%5.1f", []any{"v", 2.25}},
+	}
+	uncached := make([]string, len(testCases))
+	for i, tc := range testCases {
+		uncached[i] = xprint.Printf(tc.format, tc.args...)
+	}
+
+	xprint.CacheFormats(true)
+	defer xprint.CacheFormats(false)
+	for round := range 2 {
+		for i, tc := range testCases {
+			if got := xprint.Printf(tc.format, tc.args...); got != uncached[i] {
+				t.Errorf("round %d, %q: expected %q, got %q", round, tc.format, uncached[i], got)
+			}
+			if got := string(xprint.Appendf(nil, tc.format, tc.args...)); got != uncached[i] {
+				t.Errorf("round %d, Appendf %q: expected %q, got %q", round, tc.format, uncached[i], got)
+			}
+		}
+	}
+
+	html := xprint.Printer{Escape: xprint.EscapeHTML}
+	if got := html.Sprintf("<b>%s</b> %v", "<i>", "&"); got != "<b>&lt;i&gt;</b> &amp;" {
+		t.Errorf("escaped: got %q", got)
+	}
+
+	base, other := errors.New("base"), errors.New("other")
+	for range 2 {
+		err := xprint.Errorf("op %s: %w and %+w", "read", base, other)
+		if err.Error() != "op read: base and other" {
+			t.Errorf("Errorf: got %q", err.Error())
+		}
+		if !errors.Is(err, base) || !errors.Is(err, other) {
+			t.Errorf("Errorf: %v does not wrap both errors", err)
+		}
+		if errors.Unwrap(xprint.Errorf("%d: %w", 1, base)) != base {
+			t.Errorf("Errorf: single %%w is not unwrapped")
+		}
+	}
+}
+
+func TestFormatCacheStats(t *testing.T) {
+	xprint.CacheFormats(true)
+	xprint.SetFormatCacheSize(2)
+	defer func() {
+		xprint.CacheFormats(false)
+		xprint.SetFormatCacheSize(1024)
+	}()
+
+	for range 3 {
+		xprint.Printf("a %d", 1)
+		xprint.Printf("b %d", 2)
+		xprint.Printf("c %d", 3) // never cached, the cache is full
+	}
+	stats := xprint.FormatCacheStats()
+	expected := xprint.CacheStats{Hits: 4, Misses: 5, Entries: 2, Limit: 2}
+	if stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+
+	xprint.CacheFormats(false)
+	xprint.Printf("a %d", 1)
+	if got := xprint.FormatCacheStats(); got != expected {
+		t.Errorf("disabled cache: expected %+v, got %+v", expected, got)
+	}
+
+	xprint.SetFormatCacheSize(0)
+	xprint.CacheFormats(true)
+	if got := xprint.Printf("a %d", 1); got != "a 1" {
+		t.Errorf("zero-sized cache: got %q", got)
+	}
+	if got := xprint.FormatCacheStats(); got.Entries != 0 || got.Misses != 1 {
+		t.Errorf("zero-sized cache: got %+v", got)
+	}
+}
+
+// TestFormatCacheGrowth caches formats one at a time, so lookups are served
+// both before and after pending formats are published.
+func TestFormatCacheGrowth(t *testing.T) {
+	xprint.CacheFormats(true)
+	xprint.SetFormatCacheSize(40)
+	defer func() {
+		xprint.CacheFormats(false)
+		xprint.SetFormatCacheSize(1024)
+	}()
+
+	formats := make([]string, 50)
+	for i := range formats {
+		formats[i] = strings.Repeat("-", i) + "%d"
+	}
+	for round := range 3 {
+		for i, format := range formats {
+			if got, expected := xprint.Printf(format, i), formats[i][:i]+strconv.Itoa(i); got != expected {
+				t.Errorf("round %d: expected %q, got %q", round, expected, got)
+			}
+		}
+	}
+	stats := xprint.FormatCacheStats()
+	expected := xprint.CacheStats{Hits: 80, Misses: 70, Entries: 40, Limit: 40}
+	if stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
@@ -0,0 +1,80 @@
+package xprint
+
+import (
+	stdfmt "fmt"
+	"math/big"
+
+	reflect "github.com/goccy/go-reflect"
+)
+
+// handleFormatter lets values implementing fmt.Formatter, such as *big.Int and
+// *big.Float, format themselves using the directive's flags, width and
+// precision; the printer satisfies fmt.State. *big.Rat has no Format method
+// and gets its own number formatting.
+func (p *printer) handleFormatter(arg any, verb rune) bool {
+	switch v := arg.(type) {
+	case *big.Rat:
+		p.printRat(v, verb)
+	case stdfmt.Formatter:
+		p.callFormatter(v, verb)
+	default:
+		return false
+	}
+	return true
+}
+
+// callFormatter runs the Format method, recovering from panics like the other
+// method calls.
+func (p *printer) callFormatter(f stdfmt.Formatter, verb rune) {
+	defer func() {
+		if r := recover(); r != nil {
+			if v := reflect.ValueOf(f); v.Kind() == reflect.Ptr && v.IsNil() {
+				p.buf.writeString(nilAngleString)
+				return
+			}
+			p.buf.writeString(percentBangString)
+			p.buf.writeRune(verb)
+			p.buf.writeString(panicString)
+			p.buf.writeString("Format method: ")
+			p.buf.writeString(Printf("%v", r))
+			p.buf.writeByte(')')
+		}
+	}()
+	f.Format(p, verb)
+}
+
+// printRat formats a *big.Rat. %v and %s print the fraction like String,
+// the float verbs print its decimal expansion, and the integer verbs are
+// accepted for integral values only.
+func (p *printer) printRat(x *big.Rat, verb rune) {
+	if x == nil {
+		p.buf.writeString(nilAngleString)
+		return
+	}
+	switch verb {
+	case 'v', 's':
+		p.writePadded(x.String())
+	case 'f', 'F':
+		prec := 6
+		if p.fmt.precPresent {
+			prec = p.fmt.prec
+		}
+		p.writeNumber(x.FloatString(prec))
+	case 'e', 'E', 'g', 'G':
+		// Enough mantissa bits for the requested digits plus a margin, so
+		// the conversion does not change the rounded result.
+		bits := uint(64)
+		if p.fmt.precPresent && p.fmt.prec > 0 {
+			bits += uint(p.fmt.prec) * 4 //nolint:gosec // prec is positive
+		}
+		new(big.Float).SetPrec(bits).SetRat(x).Format(p, verb)
+	case 'd', 'x', 'X', 'o', 'O', 'b':
+		if !x.IsInt() {
+			p.printBadVerb(verb)
+			return
+		}
+		x.Num().Format(p, verb)
+	default:
+		p.printBadVerb(verb)
+	}
+}
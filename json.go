@@ -0,0 +1,453 @@
+package xprint
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	reflect "github.com/goccy/go-reflect"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// jsonFieldsCache maps reflect.Type to []jsonField.
+	jsonFieldsCache sync.Map
+)
+
+// jsonField is an encoded struct field.
+type jsonField struct {
+	index     []int
+	key       string // `"name":`
+	omitEmpty bool
+	quoted    bool // the ,string option applies
+	action    fieldAction
+}
+
+// printJSON renders arg as compact JSON for %j, following encoding/json:
+// json tags and their omitempty and string options, sorted map keys, []byte
+// as base64 and json.Marshaler and encoding.TextMarshaler methods, whose
+// output is compacted. Fields tagged `xprint:"redact"` or
+// `xprint:"-"` are redacted or omitted as in %v. Values that cannot be
+// represented, such as NaN, channels and functions, are written as null.
+// Pointers, maps and slices that lead back to themselves are written as null
+// where the cycle closes. A width pads the whole JSON text, on the left
+// unless the '-' flag is given; precision and other flags are ignored.
+// Unlike encoding/json, <, > and & are not escaped.
+func (p *printer) printJSON(arg any) {
+	start := len(p.buf)
+	if arg == nil {
+		p.buf.writeString("null")
+	} else {
+		p.jsonValue(reflect.ValueOf(arg))
+	}
+	p.padFrom(start)
+}
+
+func (p *printer) jsonValue(v reflect.Value) {
+	if !v.IsValid() {
+		p.buf.writeString("null")
+		return
+	}
+	t := v.Type()
+	if !v.CanInterface() {
+		// Promoted through an unexported embedded struct; methods are off limits
+		p.jsonKind(v)
+		return
+	}
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface && v.CanAddr() && reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		v = v.Addr()
+		t = v.Type()
+	}
+	if t.Implements(jsonMarshalerType) {
+		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+			p.buf.writeString("null")
+			return
+		}
+		b, err := v.Interface().(json.Marshaler).MarshalJSON() //nolint:forcetypeassert // checked by Implements
+		if err != nil || !p.jsonCompact(b) {
+			p.buf.writeString("null")
+		}
+		return
+	}
+	if t.Implements(textMarshalerType) && (v.Kind() != reflect.Ptr || !v.IsNil()) {
+		if b, err := v.Interface().(encoding.TextMarshaler).MarshalText(); err == nil { //nolint:forcetypeassert // checked by Implements
+			p.buf = appendJSONString(p.buf, b)
+			return
+		}
+	}
+	p.jsonKind(v)
+}
+
+// jsonCompact appends b, the output of a MarshalJSON method, without
+// insignificant space, as encoding/json does. It reports false, appending
+// nothing, if b is not valid JSON.
+func (p *printer) jsonCompact(b []byte) bool {
+	out := bytes.NewBuffer(p.buf)
+	if err := json.Compact(out, b); err != nil {
+		return false
+	}
+	p.buf = out.Bytes()
+	return true
+}
+
+// jsonQuoted renders v, a field with the ,string option, as encoding/json
+// does: the value is encoded inside a JSON string. Values with marshaler
+// methods ignore the option.
+func (p *printer) jsonQuoted(v reflect.Value) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			p.buf.writeString("null")
+			return
+		}
+		v = v.Elem()
+	}
+	if v.CanInterface() && (v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) ||
+		v.CanAddr() && reflect.PtrTo(v.Type()).Implements(jsonMarshalerType)) {
+		p.jsonValue(v)
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		// The string is escaped twice, once as JSON and once as its text
+		var scratch [64]byte
+		p.buf = appendJSONString(p.buf, appendJSONString(scratch[:0], v.String()))
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); math.IsInf(f, 0) || math.IsNaN(f) {
+			p.buf.writeString("null")
+			return
+		}
+		fallthrough
+	default:
+		p.buf.writeByte('"')
+		p.jsonKind(v)
+		p.buf.writeByte('"')
+	}
+}
+
+// jsonKind renders v by its kind, without consulting its methods.
+func (p *printer) jsonKind(v reflect.Value) {
+	t := v.Type()
+	switch v.Kind() {
+	case reflect.Bool:
+		p.buf = strconv.AppendBool(p.buf, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p.buf = strconv.AppendInt(p.buf, v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p.buf = strconv.AppendUint(p.buf, v.Uint(), 10)
+	case reflect.Float32:
+		p.buf = appendJSONFloat(p.buf, v.Float(), 32)
+	case reflect.Float64:
+		p.buf = appendJSONFloat(p.buf, v.Float(), 64)
+	case reflect.String:
+		p.jsonString(v.String())
+	case reflect.Slice:
+		if v.IsNil() {
+			p.buf.writeString("null")
+			return
+		}
+		if t.Elem().Kind() == reflect.Uint8 && !reflect.PtrTo(t.Elem()).Implements(jsonMarshalerType) {
+			p.buf.writeByte('"')
+			p.buf = base64.StdEncoding.AppendEncode(p.buf, v.Bytes())
+			p.buf.writeByte('"')
+			return
+		}
+		// A slice holding itself through an interface would recurse forever
+		if v.Len() == 0 {
+			p.buf.writeString("[]")
+			return
+		}
+		ptr := v.Pointer()
+		if p.visitedPtrs.visit(ptr) {
+			p.buf.writeString("null")
+			return
+		}
+		p.jsonArray(v)
+		p.visitedPtrs.leave(ptr)
+	case reflect.Array:
+		p.jsonArray(v)
+	case reflect.Map:
+		if v.IsNil() {
+			p.buf.writeString("null")
+			return
+		}
+		ptr := v.Pointer()
+		if p.visitedPtrs.visit(ptr) {
+			p.buf.writeString("null")
+			return
+		}
+		p.jsonMap(v)
+		p.visitedPtrs.leave(ptr)
+	case reflect.Struct:
+		p.jsonStruct(v)
+	case reflect.Ptr:
+		if v.IsNil() {
+			p.buf.writeString("null")
+			return
+		}
+		ptr := v.Pointer()
+		if p.visitedPtrs.visit(ptr) {
+			p.buf.writeString("null")
+			return
+		}
+		p.jsonValue(v.Elem())
+		p.visitedPtrs.leave(ptr)
+	case reflect.Interface:
+		p.jsonValue(v.Elem())
+	default:
+		p.buf.writeString("null")
+	}
+}
+
+func (p *printer) jsonString(s string) {
+	p.buf = appendJSONString(p.buf, s)
+}
+
+// appendJSONString appends s as a quoted and escaped JSON string.
+func appendJSONString[S ~string | ~[]byte](dst []byte, s S) []byte {
+	dst = append(dst, '"')
+	dst = appendJSONEscaped(dst, s)
+	return append(dst, '"')
+}
+
+// appendJSONFloat formats f like encoding/json.
+func appendJSONFloat(dst []byte, f float64, bits int) []byte {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return append(dst, "null"...)
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	n := len(dst)
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9
+		if m := len(dst) - n; m >= 4 && dst[len(dst)-4] == 'e' && dst[len(dst)-3] == '-' && dst[len(dst)-2] == '0' {
+			dst[len(dst)-2] = dst[len(dst)-1]
+			dst = dst[:len(dst)-1]
+		}
+	}
+	return dst
+}
+
+func (p *printer) jsonArray(v reflect.Value) {
+	p.buf.writeByte('[')
+	for i := range v.Len() {
+		if i > 0 {
+			p.buf.writeByte(',')
+		}
+		p.jsonValue(v.Index(i))
+	}
+	p.buf.writeByte(']')
+}
+
+func (p *printer) jsonMap(v reflect.Value) {
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		key, ok := jsonMapKey(k)
+		if !ok {
+			continue
+		}
+		entries = append(entries, entry{key, v.MapIndex(k)})
+	}
+	slices.SortFunc(entries, func(a, b entry) int { return strings.Compare(a.key, b.key) })
+
+	p.buf.writeByte('{')
+	for i, e := range entries {
+		if i > 0 {
+			p.buf.writeByte(',')
+		}
+		p.jsonString(e.key)
+		p.buf.writeByte(':')
+		p.jsonValue(e.value)
+	}
+	p.buf.writeByte('}')
+}
+
+// jsonMapKey resolves a map key to its string form as encoding/json does.
+func jsonMapKey(k reflect.Value) (string, bool) {
+	if k.Kind() == reflect.String {
+		return k.String(), true
+	}
+	if k.CanInterface() && k.Type().Implements(textMarshalerType) {
+		if k.Kind() == reflect.Ptr && k.IsNil() {
+			return "", true
+		}
+		b, err := k.Interface().(encoding.TextMarshaler).MarshalText() //nolint:forcetypeassert // checked by Implements
+		return string(b), err == nil
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), true
+	}
+	return "", false
+}
+
+func (p *printer) jsonStruct(v reflect.Value) {
+	p.buf.writeByte('{')
+	first := true
+	for _, f := range jsonFieldsOf(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || f.action == fieldOmit || f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		if !first {
+			p.buf.writeByte(',')
+		}
+		first = false
+		p.buf.writeString(f.key)
+		switch {
+		case f.action == fieldRedact:
+			p.buf.writeString(`"` + redactedString + `"`)
+		case f.quoted:
+			p.jsonQuoted(fv)
+		default:
+			p.jsonValue(fv)
+		}
+	}
+	p.buf.writeByte('}')
+}
+
+// fieldByIndex is Value.FieldByIndex that reports false instead of panicking
+// on nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// jsonFieldsOf returns the encoded fields of struct type t, flattening
+// untagged embedded structs.
+func jsonFieldsOf(t reflect.Type) []jsonField {
+	if f, ok := jsonFieldsCache.Load(t); ok {
+		return f.([]jsonField) //nolint:forcetypeassert // cache only holds []jsonField
+	}
+	var all []jsonField
+	collectJSONFields(t, nil, &all)
+
+	// Of several fields with the same name only the shallowest is encoded,
+	// and none if that depth is ambiguous.
+	type best struct{ depth, count int }
+	names := make(map[string]best, len(all))
+	for _, f := range all {
+		b, ok := names[f.key]
+		switch {
+		case !ok || len(f.index) < b.depth:
+			names[f.key] = best{len(f.index), 1}
+		case len(f.index) == b.depth:
+			b.count++
+			names[f.key] = b
+		}
+	}
+	fields := make([]jsonField, 0, len(all))
+	for _, f := range all {
+		if b := names[f.key]; b.depth == len(f.index) && b.count == 1 {
+			fields = append(fields, f)
+		}
+	}
+	actual, _ := jsonFieldsCache.LoadOrStore(t, fields)
+	return actual.([]jsonField) //nolint:forcetypeassert // cache only holds []jsonField
+}
+
+func collectJSONFields(t reflect.Type, index []int, fields *[]jsonField) {
+	sf := fieldsOf(t)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		idx := append(slices.Clip(index), i)
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectJSONFields(ft, idx, fields)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		f := jsonField{
+			index:  idx,
+			key:    strconv.Quote(name) + ":",
+			action: sf.action(i),
+		}
+		for opts != "" {
+			var opt string
+			opt, opts, _ = strings.Cut(opts, ",")
+			switch opt {
+			case "omitempty":
+				f.omitEmpty = true
+			case "string":
+				f.quoted = quotable(field.Type)
+			}
+		}
+		*fields = append(*fields, f)
+	}
+}
+
+// quotable reports whether the ,string option applies to a field of type t:
+// booleans, numbers, strings and unnamed pointers to them.
+func quotable(t reflect.Type) bool {
+	if t.Name() == "" && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package xprint_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+type jsonBase struct {
+	ID      int    `json:"id"`
+	Created string `json:"created,omitempty"`
+}
+
+type jsonUser struct {
+	jsonBase
+	Name     string            `json:"name"`
+	Email    string            `json:"email,omitempty"`
+	Tags     []string          `json:"tags"`
+	Meta     map[string]any    `json:"meta"`
+	Raw      []byte            `json:"raw"`
+	Scores   map[int]float64   `json:"scores"`
+	Parent   *jsonUser         `json:"parent"`
+	Addr     netip.Addr        `json:"addr"`
+	When     time.Time         `json:"when"`
+	Ignored  string            `json:"-"`
+	Labels   map[string]string `json:",omitempty"`
+	internal int
+}
+
+// jsonSpaced returns JSON with insignificant space, which %j compacts.
+type jsonSpaced struct{}
+
+func (jsonSpaced) MarshalJSON() ([]byte, error) {
+	return []byte("{ \"a\" : [1, 2],\n \"b\": \"x y\" }"), nil
+}
+
+type jsonOptions struct {
+	Count  int        `json:"count,string"`
+	Ratio  float64    `json:"ratio,omitempty,string"`
+	Zero   float64    `json:"zero,string,omitempty"`
+	Name   string     `json:"name,string"`
+	On     *bool      `json:"on,string"`
+	Nil    *int       `json:"nil,string"`
+	Many   []int      `json:"many,string"`
+	Kept   string     `json:"kept,omitemptyx"`
+	Spaced jsonSpaced `json:"spaced,string"`
+	When   time.Time  `json:"when,string"`
+}
+
+func TestJSONVerb(t *testing.T) {
+	u := jsonUser{
+		jsonBase: jsonBase{ID: 7},
+		Name:     "Ana \"<x>\"\n",
+		Tags:     []string{"a", "b"},
+		Meta:     map[string]any{"z": 1.5, "a": nil, "m": []any{true, 1e21, 1e-7}},
+		Raw:      []byte("hello"),
+		Scores:   map[int]float64{10: 1, 9: 2},
+		Addr:     netip.MustParseAddr("10.0.0.1"),
+		When:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Ignored:  "x",
+		internal: 1,
+	}
+	u.Parent = &jsonUser{Name: "root"}
+
+	values := []any{
+		u,
+		&u,
+		[]int(nil),
+		map[string]int{"b": 2, "a": 1},
+		"plain",
+		42,
+		3.0,
+		big.NewInt(12345),
+		nil,
+		jsonOptions{Count: 12, Ratio: 0.5, Name: "a \"b\"", On: new(bool), Many: []int{1}},
+		[]jsonSpaced{{}},
+	}
+	for _, v := range values {
+		// %j does not escape HTML characters
+		var sb strings.Builder
+		enc := json.NewEncoder(&sb)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			t.Fatal(err)
+		}
+		expected := strings.TrimSuffix(sb.String(), "\n")
+		if o := xprint.Printf("%j", v); o != expected {
+			t.Errorf("Expected %s, got %s", expected, o)
+		}
+	}
+}
+
+func TestJSONVerbCycles(t *testing.T) {
+	m := map[string]any{"a": 1}
+	m["self"] = m
+	s := []any{1, nil}
+	s[1] = s
+	testCases := []struct {
+		format   string
+		arg      any
+		expected string
+	}{
+		{"%j", m, `{"a":1,"self":null}`},
+		{"%j", s, `[1,null]`},
+		{"%j", []any{m, m}, `[{"a":1,"self":null},{"a":1,"self":null}]`},
+		{"%j", []any{}, `[]`},
+		{"%8j", []int{1, 2}, `   [1,2]`},
+		{"%-8j|", "é", `"é"     |`},
+		{"%6j", nil, `  null`},
+		{"%.1j", 1.25, `1.25`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if o := xprint.Printf(tc.format, tc.arg); o != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, o)
+			}
+		})
+	}
+}
+
+func TestJSONVerbRedaction(t *testing.T) {
+	type login struct {
+		User     string `json:"user"`
+		Password string `json:"password" xprint:"redact"`
+		Session  string `xprint:"-"`
+	}
+	if o := xprint.Printf("login %j", login{"alice", "hunter2", "s"}); o != `login {"user":"alice","password":"[REDACTED]"}` {
+		t.Errorf("Unexpected %s", o)
+	}
+}
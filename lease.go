@@ -0,0 +1,51 @@
+package xprint
+
+// Lease is formatted output held in a pooled buffer. Callers that write the
+// output right away, to a socket or a file, skip the copy a string result
+// costs and give the buffer back with Release.
+//
+// The bytes are valid until Release and must not be kept after it. Copies
+// of a released Lease return nil from Bytes and do nothing on Release, even
+// once the buffer is leased again.
+type Lease struct {
+	p   *printer
+	gen uint64
+}
+
+// Leasef formats according to a format specifier into a pooled buffer. The
+// Lease must be released once its bytes are no longer used.
+func Leasef(format string, args ...any) Lease {
+	p := newPrinter()
+	p.printf(format, args)
+	return Lease{p: p, gen: p.gen.Load()}
+}
+
+// Bytes returns the formatted output, or nil if the lease was released.
+// Appending to the result never writes to the pooled buffer.
+func (l Lease) Bytes() []byte {
+	if !l.held() {
+		return nil
+	}
+	return l.p.buf[:len(l.p.buf):len(l.p.buf)]
+}
+
+// Len returns the length of the formatted output, or 0 if the lease was
+// released.
+func (l Lease) Len() int {
+	return len(l.Bytes())
+}
+
+// Release returns the buffer to the pool. Releasing a lease again has no
+// effect.
+func (l Lease) Release() {
+	// Claiming the generation first frees the printer once, even if copies
+	// of l are released concurrently
+	if l.p != nil && l.p.gen.CompareAndSwap(l.gen, l.gen+1) {
+		l.p.free()
+	}
+}
+
+// held reports whether the printer has not been freed since l was taken.
+func (l Lease) held() bool {
+	return l.p != nil && l.p.gen.Load() == l.gen
+}
@@ -0,0 +1,114 @@
+package xprint_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func TestLeasef(t *testing.T) {
+	l := xprint.Leasef("%s=%d;%5.2f", "key", 42, 3.14159)
+	if got, expected := string(l.Bytes()), xprint.Printf("%s=%d;%5.2f", "key", 42, 3.14159); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if l.Len() != len("key=42; 3.14") {
+		t.Errorf("expected length %d, got %d", len("key=42; 3.14"), l.Len())
+	}
+
+	// Appending to the bytes must not reach the pooled buffer
+	b := append(l.Bytes(), "tail"...)
+	if string(l.Bytes()) != "key=42; 3.14" || string(b) != "key=42; 3.14tail" {
+		t.Errorf("append changed the lease: %q, %q", l.Bytes(), b)
+	}
+
+	stale := l
+	l.Release()
+	if l.Bytes() != nil || stale.Len() != 0 {
+		t.Errorf("released lease still returns %q", l.Bytes())
+	}
+
+	// A stale copy must not release the next lease of the same buffer
+	next := xprint.Leasef("%s", "next")
+	stale.Release()
+	l.Release()
+	other := xprint.Leasef("%s", "other")
+	if string(next.Bytes()) != "next" || string(other.Bytes()) != "other" {
+		t.Errorf("stale release freed a live lease: %q, %q", next.Bytes(), other.Bytes())
+	}
+	next.Release()
+	other.Release()
+}
+
+// TestLeaseNoAliasing holds many leases at once from several goroutines and
+// checks no two of them share a buffer. Run it with -race.
+func TestLeaseNoAliasing(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			leases := make([]xprint.Lease, 16)
+			for round := range 50 {
+				for i := range leases {
+					leases[i] = xprint.Leasef("g%d r%d l%d %s", g, round, i, strings.Repeat("x", i*100))
+				}
+				for i, l := range leases {
+					expected := xprint.Printf("g%d r%d l%d %s", g, round, i, strings.Repeat("x", i*100))
+					if string(l.Bytes()) != expected {
+						t.Errorf("lease %d of goroutine %d was overwritten", i, g)
+						return
+					}
+					// Writing to the buffer is allowed until Release
+					copy(l.Bytes(), "G")
+					l.Release()
+					l.Release()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestLargeStringsNoAliasing checks that strings handed the buffers of large
+// outputs are never written to by later calls. Run it with -race.
+func TestLargeStringsNoAliasing(t *testing.T) {
+	big := strings.Repeat("y", 100<<10)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			kept := make([]string, 0, 20)
+			for i := range cap(kept) {
+				kept = append(kept, xprint.Printf("%d:%d:%s", g, i, big))
+				// Small outputs reuse pooled buffers
+				_ = xprint.Printf("%d %s", i, "small")
+				_ = xprint.Sprintf("%s%s", big[:i], big[:i])
+			}
+			for i, s := range kept {
+				prefix := xprint.Printf("%d:%d:", g, i)
+				if !strings.HasPrefix(s, prefix) || len(s) != len(prefix)+len(big) || strings.Count(s, "y") != len(big) {
+					t.Errorf("result %d of goroutine %d was overwritten", i, g)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLeaseAllocs(t *testing.T) {
+	var out bytes.Buffer
+	out.Grow(64)
+	allocs := testing.AllocsPerRun(100, func() {
+		out.Reset()
+		l := xprint.Leasef("%s=%s", "key", "value")
+		out.Write(l.Bytes())
+		l.Release()
+	})
+	if allocs > 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
@@ -0,0 +1,171 @@
+package xprint
+
+// Locale describes how numbers are localized.
+type Locale struct {
+	// Group separates digit groups of the integer part when the ' flag is set.
+	Group string
+	// Decimal replaces the '.' of floating-point output. Empty keeps '.'.
+	Decimal string
+	// GroupSize is the number of digits per group; zero means 3.
+	GroupSize int
+}
+
+// Common locales.
+var (
+	LocaleEnglish = Locale{Group: ",", Decimal: ".", GroupSize: 3}
+	LocaleGerman  = Locale{Group: ".", Decimal: ",", GroupSize: 3}
+	LocaleFrench  = Locale{Group: " ", Decimal: ",", GroupSize: 3}
+	LocaleSwiss   = Locale{Group: "'", Decimal: ".", GroupSize: 3}
+)
+
+// defaultLocale is used by the ' flag when no locale is configured.
+var defaultLocale = Locale{Group: ",", Decimal: ".", GroupSize: 3}
+
+// localizes reports whether number output of the current directive needs
+// the localization pass. It is false on the default path and for %#v, whose
+// Go syntax keeps plain numbers.
+func (p *printer) localizes() bool {
+	return (p.fmt.group || p.locale != nil) && !p.fmt.sharpV
+}
+
+// printLocalized runs format, which writes a number, with width handling
+// deferred, then rewrites the number using the active locale and pads the
+// result. It reports what format reports; nothing is written when that is
+// false.
+func (p *printer) printLocalized(format func() bool) bool {
+	wid, widPresent, zero, minus := p.fmt.wid, p.fmt.widPresent, p.fmt.zero, p.fmt.minus
+	p.fmt.wid, p.fmt.widPresent, p.fmt.zero, p.fmt.minus = 0, false, false, false
+	grouping, loc := p.fmt.group, p.locale
+	p.fmt.group, p.locale = false, nil
+	start := len(p.buf)
+	ok := format()
+	p.fmt.wid, p.fmt.widPresent, p.fmt.zero, p.fmt.minus = wid, widPresent, zero, minus
+	p.fmt.group, p.locale = grouping, loc
+	if !ok {
+		return false
+	}
+
+	if loc == nil {
+		loc = &defaultLocale
+	}
+	// Grouping only makes sense for decimal output.
+	group := grouping && loc.Group != ""
+	switch p.verb {
+	case 'b', 'B', 'o', 'O', 'x', 'X':
+		group = false
+	}
+
+	var scratch [64]byte
+	num := append(scratch[:0], p.buf[start:]...)
+	p.buf = p.buf[:start]
+
+	width := 0
+	if widPresent {
+		width = wid - localizedWidth(num, loc, group)
+	}
+	signLen := 0
+	if len(num) > 0 && (num[0] == '-' || num[0] == '+' || num[0] == ' ') {
+		signLen = 1
+	}
+	switch {
+	case minus:
+		p.appendLocalized(num, loc, group)
+		p.writePadding(width, ' ')
+	case zero:
+		p.buf.write(num[:signLen])
+		p.writePadding(width, '0')
+		p.appendLocalized(num[signLen:], loc, group)
+	default:
+		p.writePadding(width, ' ')
+		p.appendLocalized(num, loc, group)
+	}
+	return true
+}
+
+// appendLocalized appends num, grouping every integer digit run and
+// replacing decimal points. Digits after a decimal point or an exponent
+// marker are left alone.
+func (p *printer) appendLocalized(num []byte, loc *Locale, group bool) {
+	size := loc.GroupSize
+	if size <= 0 {
+		size = 3
+	}
+	for i := 0; i < len(num); {
+		c := num[i]
+		if !isDigit(c) {
+			if c == '.' && loc.Decimal != "" {
+				p.buf.writeString(loc.Decimal)
+			} else {
+				p.buf.writeByte(c)
+			}
+			i++
+			continue
+		}
+		j := i
+		for j < len(num) && isDigit(num[j]) {
+			j++
+		}
+		if !group || isFractionOrExponent(num, i) {
+			p.buf.write(num[i:j])
+		} else {
+			for k := i; k < j; k++ {
+				if k > i && (j-k)%size == 0 {
+					p.buf.writeString(loc.Group)
+				}
+				p.buf.writeByte(num[k])
+			}
+		}
+		i = j
+	}
+}
+
+// localizedWidth returns the number of cells appendLocalized produces for
+// num. Separators such as U+202F take one cell but several bytes.
+func localizedWidth(num []byte, loc *Locale, group bool) int {
+	size := loc.GroupSize
+	if size <= 0 {
+		size = 3
+	}
+	n := 0
+	for i := 0; i < len(num); {
+		c := num[i]
+		if !isDigit(c) {
+			if c == '.' && loc.Decimal != "" {
+				n += StringWidth(loc.Decimal)
+			} else {
+				n++
+			}
+			i++
+			continue
+		}
+		j := i
+		for j < len(num) && isDigit(num[j]) {
+			j++
+		}
+		n += j - i
+		if group && !isFractionOrExponent(num, i) {
+			n += (j - i - 1) / size * StringWidth(loc.Group)
+		}
+		i = j
+	}
+	return n
+}
+
+// isFractionOrExponent reports whether the digit run starting at i follows a
+// decimal point or an exponent marker.
+func isFractionOrExponent(num []byte, i int) bool {
+	if i == 0 {
+		return false
+	}
+	switch num[i-1] {
+	case '.', 'e', 'E', 'p', 'P':
+		return true
+	case '+', '-':
+		return i >= 2 && (num[i-2] == 'e' || num[i-2] == 'E' || num[i-2] == 'p' || num[i-2] == 'P')
+	}
+	return false
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
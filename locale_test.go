@@ -0,0 +1,85 @@
+package xprint_test
+
+import (
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+type amount int64
+
+func TestGroupFlag(t *testing.T) {
+	testCases := []struct {
+		format   string
+		arg      any
+		expected string
+	}{
+		{"%'d", 1234567, "1,234,567"},
+		{"%'d", -1234, "-1,234"},
+		{"%'d", 999, "999"},
+		{"%'d", uint64(18446744073709551615), "18,446,744,073,709,551,615"},
+		{"%'+d", 1234567, "+1,234,567"},
+		{"%'12d", 1234567, "   1,234,567"},
+		{"%'-12d|", 1234567, "1,234,567   |"},
+		{"%'.2f", 1234567.891, "1,234,567.89"},
+		{"%'e", 1234567.0, "1.234567e+06"},
+		{"%'x", 1234567, "12d687"},
+		{"%d", 1234567, "1234567"},
+		{"%'d", amount(1234567), "1,234,567"},
+		{"%'12d", amount(-1234567), "  -1,234,567"},
+		{"%'d", []int{1234567, 2}, "[1,234,567 2]"},
+		{"%'v", map[string]float64{"a": 12345.5}, "map[a:12,345.5]"},
+		{"%'c", 65, "A"},
+		{"%'s", 1234567, "%!s(int=1234567)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if o := xprint.Printf(tc.format, tc.arg); o != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, o)
+			}
+		})
+	}
+}
+
+func TestPrinterLocale(t *testing.T) {
+	de := xprint.NewPrinter(xprint.LocaleGerman)
+	testCases := []struct {
+		format   string
+		arg      any
+		expected string
+	}{
+		{"%'d", 1234567, "1.234.567"},
+		{"%'.2f", 1234567.891, "1.234.567,89"},
+		{"%.2f", 3.5, "3,50"},
+		{"%v", 42, "42"},
+		{"%s", "1.5", "1.5"},
+		{"%v", struct {
+			N int
+			F float64
+		}{1234, 1.5}, "{1234 1,5}"},
+		{"%'v", []any{amount(1234), 2.5}, "[1.234 2,5]"},
+		{"%#v", 1.5, "1.5"},
+		{"%'v", []any{amount(1234), int8(-5)}, "[1.234 -5]"},
+		{"%#v", 1234, "1234"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if o := de.Sprintf(tc.format, tc.arg); o != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, o)
+			}
+		})
+	}
+
+	// Multi-byte separators pad by cell width
+	narrow := xprint.NewPrinter(xprint.Locale{Group: "\u202f", Decimal: ",", GroupSize: 3})
+	if o := narrow.Sprintf("[%'12d|%-'12.1f]", 1234567, 1234.5); o != "[   1\u202f234\u202f567|1\u202f234,5     ]" {
+		t.Errorf("Expected cell-width padding, got %q", o)
+	}
+
+	var zero xprint.Printer
+	if o := zero.Sprintf("%d %.2f", 1234567, 1.5); o != "1234567 1.50" {
+		t.Errorf("Expected zero Printer to match Printf, got %s", o)
+	}
+}
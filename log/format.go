@@ -0,0 +1,100 @@
+package log
+
+import (
+	"reflect"
+	"time"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// itoa appends the decimal form of i to buf, zero-padded to wid digits.
+// A negative width avoids zero-padding.
+func itoa(buf []byte, i int, wid int) []byte {
+	// Assemble decimal in reverse order.
+	var b [20]byte
+	bp := len(b) - 1
+	for i >= 10 || wid > 1 {
+		wid--
+		q := i / 10
+		b[bp] = byte('0' + i - q*10)
+		bp--
+		i = q
+	}
+	// i < 10
+	b[bp] = byte('0' + i)
+	return append(buf, b[bp:]...)
+}
+
+// formatHeader appends the log header to buf, in the same layout as the
+// standard library logger:
+//   - prefix (if the Lmsgprefix flag is not set),
+//   - date and/or time (if corresponding flags are provided),
+//   - file and line number (if corresponding flags are provided),
+//   - prefix (if the Lmsgprefix flag is set).
+func formatHeader(buf []byte, t time.Time, prefix string, flag int, file string, line int) []byte {
+	if flag&Lmsgprefix == 0 {
+		buf = append(buf, prefix...)
+	}
+	if flag&(Ldate|Ltime|Lmicroseconds) != 0 {
+		if flag&LUTC != 0 {
+			t = t.UTC()
+		}
+		if flag&Ldate != 0 {
+			year, month, day := t.Date()
+			buf = itoa(buf, year, 4)
+			buf = append(buf, '/')
+			buf = itoa(buf, int(month), 2)
+			buf = append(buf, '/')
+			buf = itoa(buf, day, 2)
+			buf = append(buf, ' ')
+		}
+		if flag&(Ltime|Lmicroseconds) != 0 {
+			hour, minute, sec := t.Clock()
+			buf = itoa(buf, hour, 2)
+			buf = append(buf, ':')
+			buf = itoa(buf, minute, 2)
+			buf = append(buf, ':')
+			buf = itoa(buf, sec, 2)
+			if flag&Lmicroseconds != 0 {
+				buf = append(buf, '.')
+				buf = itoa(buf, t.Nanosecond()/1e3, 6)
+			}
+			buf = append(buf, ' ')
+		}
+	}
+	if flag&(Lshortfile|Llongfile) != 0 {
+		if flag&Lshortfile != 0 {
+			short := file
+			for i := len(file) - 1; i > 0; i-- {
+				if file[i] == '/' {
+					short = file[i+1:]
+					break
+				}
+			}
+			file = short
+		}
+		buf = append(buf, file...)
+		buf = append(buf, ':')
+		buf = itoa(buf, line, -1)
+		buf = append(buf, ": "...)
+	}
+	if flag&Lmsgprefix != 0 {
+		buf = append(buf, prefix...)
+	}
+	return buf
+}
+
+// appendPrint appends v formatted with xprint.Append, spaced as fmt.Sprint
+// spaces operands: only between two operands that are not strings.
+func appendPrint(buf []byte, v []any) []byte {
+	prevString := false
+	for i, arg := range v {
+		isString := arg != nil && reflect.TypeOf(arg).Kind() == reflect.String
+		if i > 0 && !isString && !prevString {
+			buf = append(buf, ' ')
+		}
+		buf = xprint.Append(buf, arg)
+		prevString = isString
+	}
+	return buf
+}
@@ -0,0 +1,301 @@
+/*
+Package log is a drop-in replacement for the standard library log package.
+
+It mirrors log.Logger (prefix, flags, output, the Print/Fatal/Panic families and
+a default logger) but formats every message with xprint, appending straight into
+the logger's pooled output buffer instead of going through fmt.Sprintf.
+Switching is a one-line import change:
+
+	import "gopkg.hlmpn.dev/pkg/xprint/log"
+*/
+package log
+
+import (
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+// These flags define which text to prefix to each log entry generated by the Logger.
+// They are identical to the flags of the standard library log package.
+const (
+	Ldate         = 1 << iota     // the date in the local time zone: 2009/01/23
+	Ltime                         // the time in the local time zone: 01:23:23
+	Lmicroseconds                 // microsecond resolution: 01:23:23.123123.  assumes Ltime.
+	Llongfile                     // full file name and line number: /a/b/c/d.go:23
+	Lshortfile                    // final file name element and line number: d.go:23. overrides Llongfile
+	LUTC                          // if Ldate or Ltime is set, use UTC rather than the local time zone
+	Lmsgprefix                    // move the "prefix" from the beginning of the line to before the message
+	LstdFlags     = Ldate | Ltime // initial values for the standard logger
+)
+
+// maxBufferSize is the largest buffer kept in bufferPool.
+const maxBufferSize = 64 << 10
+
+// A Logger writes lines of output to an io.Writer. Each logging operation makes
+// a single call to the Writer's Write method. A Logger can be used
+// simultaneously from multiple goroutines.
+type Logger struct {
+	outMu sync.Mutex
+	out   io.Writer
+
+	prefix    atomic.Pointer[string]
+	flag      atomic.Int32
+	isDiscard atomic.Bool
+}
+
+// New creates a new Logger writing to out. The prefix appears at the beginning
+// of each line, or after the header if the Lmsgprefix flag is provided.
+func New(out io.Writer, prefix string, flag int) *Logger {
+	l := new(Logger)
+	l.SetOutput(out)
+	l.SetPrefix(prefix)
+	l.SetFlags(flag)
+	return l
+}
+
+var std = New(os.Stderr, "", LstdFlags)
+
+// Default returns the standard logger used by the package-level output functions.
+func Default() *Logger { return std }
+
+// SetOutput sets the output destination for the logger.
+func (l *Logger) SetOutput(w io.Writer) {
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	l.out = w
+	l.isDiscard.Store(w == io.Discard)
+}
+
+// Writer returns the output destination for the logger.
+func (l *Logger) Writer() io.Writer {
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	return l.out
+}
+
+// Flags returns the output flags for the logger.
+func (l *Logger) Flags() int {
+	return int(l.flag.Load())
+}
+
+// SetFlags sets the output flags for the logger.
+func (l *Logger) SetFlags(flag int) {
+	l.flag.Store(int32(flag)) //nolint:gosec // flags fit in 7 bits
+}
+
+// Prefix returns the output prefix for the logger.
+func (l *Logger) Prefix() string {
+	if p := l.prefix.Load(); p != nil {
+		return *p
+	}
+	return ""
+}
+
+// SetPrefix sets the output prefix for the logger.
+func (l *Logger) SetPrefix(prefix string) {
+	l.prefix.Store(&prefix)
+}
+
+// Output writes the output for a logging event. The string s contains the text
+// to print after the prefix specified by the flags of the Logger. A newline is
+// appended if the last character of s is not already a newline. Calldepth is
+// the count of the number of frames to skip when computing the file name and
+// line number if Llongfile or Lshortfile is set.
+func (l *Logger) Output(calldepth int, s string) error {
+	return l.output(calldepth, func(b []byte) []byte { return append(b, s...) })
+}
+
+// output formats the header and lets appendOutput write the message directly
+// into the pooled line buffer.
+func (l *Logger) output(calldepth int, appendOutput func([]byte) []byte) error {
+	if l.isDiscard.Load() {
+		return nil
+	}
+
+	now := time.Now() // get this early.
+
+	prefix := l.Prefix()
+	flag := l.Flags()
+
+	var file string
+	var line int
+	if flag&(Lshortfile|Llongfile) != 0 {
+		var ok bool
+		_, file, line, ok = runtime.Caller(calldepth + 1)
+		if !ok {
+			file = "???"
+			line = 0
+		}
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	*buf = formatHeader(*buf, now, prefix, flag, file, line)
+	headerLen := len(*buf)
+	*buf = appendOutput(*buf)
+	if len(*buf) == headerLen || (*buf)[len(*buf)-1] != '\n' {
+		*buf = append(*buf, '\n')
+	}
+
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+	_, err := l.out.Write(*buf)
+	return err //nolint:wrapcheck // mirrors log.Logger.Output
+}
+
+// Print calls l.Output to print to the logger. Arguments are handled in the
+// manner of fmt.Print.
+func (l *Logger) Print(v ...any) {
+	l.output(1, func(b []byte) []byte { return appendPrint(b, v) }) //nolint:errcheck // like log.Logger
+}
+
+// Printf calls l.Output to print to the logger. Arguments are handled in the
+// manner of xprint.Printf.
+func (l *Logger) Printf(format string, v ...any) {
+	l.output(1, func(b []byte) []byte { return xprint.Appendf(b, format, v...) }) //nolint:errcheck // like log.Logger
+}
+
+// Println calls l.Output to print to the logger. Arguments are handled in the
+// manner of xprint.Appendln.
+func (l *Logger) Println(v ...any) {
+	l.output(1, func(b []byte) []byte { return xprint.Appendln(b, v...) }) //nolint:errcheck // like log.Logger
+}
+
+// Fatal is equivalent to l.Print() followed by a call to os.Exit(1).
+func (l *Logger) Fatal(v ...any) {
+	l.output(1, func(b []byte) []byte { return appendPrint(b, v) }) //nolint:errcheck // exiting anyway
+	os.Exit(1)
+}
+
+// Fatalf is equivalent to l.Printf() followed by a call to os.Exit(1).
+func (l *Logger) Fatalf(format string, v ...any) {
+	l.output(1, func(b []byte) []byte { return xprint.Appendf(b, format, v...) }) //nolint:errcheck // exiting anyway
+	os.Exit(1)
+}
+
+// Fatalln is equivalent to l.Println() followed by a call to os.Exit(1).
+func (l *Logger) Fatalln(v ...any) {
+	l.output(1, func(b []byte) []byte { return xprint.Appendln(b, v...) }) //nolint:errcheck // exiting anyway
+	os.Exit(1)
+}
+
+// Panic is equivalent to l.Print() followed by a call to panic().
+func (l *Logger) Panic(v ...any) {
+	s := string(appendPrint(nil, v))
+	l.Output(2, s) //nolint:errcheck // panicking anyway
+	panic(s)
+}
+
+// Panicf is equivalent to l.Printf() followed by a call to panic().
+func (l *Logger) Panicf(format string, v ...any) {
+	s := xprint.Sprintf(format, v...)
+	l.Output(2, s) //nolint:errcheck // panicking anyway
+	panic(s)
+}
+
+// Panicln is equivalent to l.Println() followed by a call to panic().
+func (l *Logger) Panicln(v ...any) {
+	s := string(xprint.Appendln(nil, v...))
+	l.Output(2, s) //nolint:errcheck // panicking anyway
+	panic(s)
+}
+
+// SetOutput sets the output destination for the standard logger.
+func SetOutput(w io.Writer) { std.SetOutput(w) }
+
+// Flags returns the output flags for the standard logger.
+func Flags() int { return std.Flags() }
+
+// SetFlags sets the output flags for the standard logger.
+func SetFlags(flag int) { std.SetFlags(flag) }
+
+// Prefix returns the output prefix for the standard logger.
+func Prefix() string { return std.Prefix() }
+
+// SetPrefix sets the output prefix for the standard logger.
+func SetPrefix(prefix string) { std.SetPrefix(prefix) }
+
+// Writer returns the output destination for the standard logger.
+func Writer() io.Writer { return std.Writer() }
+
+// Output writes the output for a logging event on the standard logger.
+func Output(calldepth int, s string) error {
+	return std.Output(calldepth+1, s) // +1 for this frame.
+}
+
+// Print calls Output to print to the standard logger.
+func Print(v ...any) {
+	std.output(1, func(b []byte) []byte { return appendPrint(b, v) }) //nolint:errcheck // like log.Print
+}
+
+// Printf calls Output to print to the standard logger.
+func Printf(format string, v ...any) {
+	std.output(1, func(b []byte) []byte { return xprint.Appendf(b, format, v...) }) //nolint:errcheck // like log.Printf
+}
+
+// Println calls Output to print to the standard logger.
+func Println(v ...any) {
+	std.output(1, func(b []byte) []byte { return xprint.Appendln(b, v...) }) //nolint:errcheck // like log.Println
+}
+
+// Fatal is equivalent to Print() followed by a call to os.Exit(1).
+func Fatal(v ...any) {
+	std.output(1, func(b []byte) []byte { return appendPrint(b, v) }) //nolint:errcheck // exiting anyway
+	os.Exit(1)
+}
+
+// Fatalf is equivalent to Printf() followed by a call to os.Exit(1).
+func Fatalf(format string, v ...any) {
+	std.output(1, func(b []byte) []byte { return xprint.Appendf(b, format, v...) }) //nolint:errcheck // exiting anyway
+	os.Exit(1)
+}
+
+// Fatalln is equivalent to Println() followed by a call to os.Exit(1).
+func Fatalln(v ...any) {
+	std.output(1, func(b []byte) []byte { return xprint.Appendln(b, v...) }) //nolint:errcheck // exiting anyway
+	os.Exit(1)
+}
+
+// Panic is equivalent to Print() followed by a call to panic().
+func Panic(v ...any) {
+	s := string(appendPrint(nil, v))
+	std.Output(2, s) //nolint:errcheck // panicking anyway
+	panic(s)
+}
+
+// Panicf is equivalent to Printf() followed by a call to panic().
+func Panicf(format string, v ...any) {
+	s := xprint.Sprintf(format, v...)
+	std.Output(2, s) //nolint:errcheck // panicking anyway
+	panic(s)
+}
+
+// Panicln is equivalent to Println() followed by a call to panic().
+func Panicln(v ...any) {
+	s := string(xprint.Appendln(nil, v...))
+	std.Output(2, s) //nolint:errcheck // panicking anyway
+	panic(s)
+}
+
+var bufferPool = sync.Pool{New: func() any { return new([]byte) }}
+
+func getBuffer() *[]byte {
+	p := bufferPool.Get().(*[]byte) //nolint:forcetypeassert // pool only holds *[]byte
+	*p = (*p)[:0]
+	return p
+}
+
+func putBuffer(p *[]byte) {
+	// Proper usage of a sync.Pool requires each entry to have approximately
+	// the same memory cost, so oversized buffers are dropped.
+	if cap(*p) > maxBufferSize {
+		*p = nil
+	}
+	bufferPool.Put(p)
+}
@@ -0,0 +1,96 @@
+package log_test
+
+import (
+	"bytes"
+	stdlog "log"
+	"strings"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint/log"
+)
+
+func TestLoggerMatchesStdlib(t *testing.T) {
+	testCases := []struct {
+		name   string
+		prefix string
+		flag   int
+		format string // Print is used when empty
+		args   []any
+	}{
+		{"plain", "", 0, "hello %s", []any{"world"}},
+		{"prefix", "app: ", 0, "count=%d", []any{42}},
+		{"msgprefix", "app: ", log.Lmsgprefix, "ok %t", []any{true}},
+		{"no args", "", 0, "no args", nil},
+		{"nil operand", "", 0, "value: %v", []any{nil}},
+		{"trailing newline", "", 0, "line\n", nil},
+		{"print strings", "", 0, "", []any{"a", "b"}},
+		{"print mixed", "app: ", 0, "", []any{"a", 1, 2, "b"}},
+		{"print values", "", 0, "", []any{1, 2.5, true, nil, "x", []int{3}}},
+		{"print newline", "", 0, "", []any{"done\n"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var xb, sb bytes.Buffer
+			xl := log.New(&xb, tc.prefix, tc.flag)
+			sl := stdlog.New(&sb, tc.prefix, tc.flag)
+			if tc.format == "" {
+				xl.Print(tc.args...)
+				sl.Print(tc.args...)
+			} else {
+				xl.Printf(tc.format, tc.args...)
+				sl.Printf(tc.format, tc.args...)
+			}
+			if xb.String() != sb.String() {
+				t.Errorf("Expected %q, got %q", sb.String(), xb.String())
+			}
+		})
+	}
+}
+
+func TestLoggerShortfile(t *testing.T) {
+	var xb bytes.Buffer
+	xl := log.New(&xb, "", log.Lshortfile)
+	xl.Printf("%v", []int{1, 2, 3})
+	if !strings.HasPrefix(xb.String(), "log_test.go:") || !strings.HasSuffix(xb.String(), ": [1 2 3]\n") {
+		t.Errorf("Expected caller header and message, got %q", xb.String())
+	}
+}
+
+func TestLoggerPrintln(t *testing.T) {
+	var xb, sb bytes.Buffer
+	xl := log.New(&xb, "> ", 0)
+	sl := stdlog.New(&sb, "> ", 0)
+	xl.Println("a", 1, "b", 2.5, nil)
+	sl.Println("a", 1, "b", 2.5, nil)
+	if xb.String() != sb.String() {
+		t.Errorf("Expected %q, got %q", sb.String(), xb.String())
+	}
+}
+
+func TestLoggerPanicf(t *testing.T) {
+	var xb bytes.Buffer
+	xl := log.New(&xb, "", 0)
+	defer func() {
+		r := recover()
+		if r != "boom 7" {
+			t.Errorf("Expected panic value %q, got %v", "boom 7", r)
+		}
+		if xb.String() != "boom 7\n" {
+			t.Errorf("Expected %q, got %q", "boom 7\n", xb.String())
+		}
+	}()
+	xl.Panicf("boom %d", 7)
+}
+
+func TestDefaultLogger(t *testing.T) {
+	var xb bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	defer log.SetFlags(log.Flags())
+	log.SetOutput(&xb)
+	log.SetFlags(0)
+	log.Printf("default %s", "logger")
+	if xb.String() != "default logger\n" {
+		t.Errorf("Expected %q, got %q", "default logger\n", xb.String())
+	}
+}
@@ -14,16 +14,38 @@ func Printf(format string, args ...any) string {
 		return format
 	}
 
-	// Fast path for simple "%s" formatting with string arguments
-	if onlyContainsStringPlaceholders(format) && allArgsAreStringLike(args) {
-		return fastStringFormat(format, args)
+	// Literal text and plain %s directives of short outputs need no
+	// printer; the first other directive hands the output so far over to one
+	size, largest := estimateSize(format, args)
+	var scratch [128]byte
+	var buf []byte
+	i, argNum := 0, 0
+	scanned := size <= len(scratch)
+	if scanned {
+		buf, i, argNum = appendPlain(scratch[:0], format, 0, args, 0, true)
+		if i == len(format) {
+			return string(buf)
+		}
 	}
 
 	p := newPrinter()
-	p.printf(format, args)
-	s := string(p.buf)
-	p.free()
-	return s
+	p.stringResult = true
+	p.presize(size, largest)
+	if t := cachedFormat(format); t != nil && len(args) >= t.args {
+		if scanned {
+			// Resume at the directive the scan stopped at, whose literal
+			// text is the tail of buf
+			p.buf = append(p.buf, buf[:len(buf)-len(t.directives[argNum].lit)]...)
+			p.printTemplate(t, args, argNum, false)
+		} else {
+			p.printTemplate(t, args, 0, false)
+		}
+	} else {
+		p.buf = append(p.buf, buf...)
+		p.argNum = argNum
+		p.printfFrom(format, i, args)
+	}
+	return p.takeString()
 }
 
 func Sprintf(format string, args ...any) string {
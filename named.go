@@ -0,0 +1,221 @@
+package xprint
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	reflect "github.com/goccy/go-reflect"
+)
+
+// namedDirective is a directive taking its argument by name.
+type namedDirective struct {
+	directive
+	name string
+}
+
+// namedTemplate is a compiled Named template.
+type namedTemplate struct {
+	directives []namedDirective
+}
+
+// templateCacheSize bounds each templateCache.
+const templateCacheSize = 1024
+
+// templateCache holds compiled templates keyed by their source. Templates are
+// expected to be constants, so entries are never evicted; once
+// templateCacheSize are cached, further templates are compiled on every use.
+type templateCache[T any] struct {
+	m       sync.Map // map[string]T
+	entries atomic.Int64
+}
+
+// get returns the compiled form of src, compiling and caching it on first
+// use.
+func (c *templateCache[T]) get(src string, compile func(string) T) T {
+	if t, ok := c.m.Load(src); ok {
+		return t.(T) //nolint:forcetypeassert // only T is stored
+	}
+	t := compile(src)
+	if c.entries.Add(1) <= templateCacheSize {
+		if _, loaded := c.m.LoadOrStore(src, t); !loaded {
+			return t
+		}
+	}
+	c.entries.Add(-1)
+	return t
+}
+
+// namedCache holds compiled Named templates.
+var namedCache templateCache[*namedTemplate]
+
+// Named formats template using named arguments taken from args, which may be
+// a map with string keys or a struct (or pointer to struct) whose exported
+// fields are matched by name, case-insensitively if there is no exact match.
+//
+// Placeholders are written {name} or {name:spec}, where spec is anything that
+// may follow % in Printf, such as {count:d} or {price:08.2f}. The printf-like
+// form %(name)spec is accepted as well, for example %(user)s. Use {{, }} and
+// %% for literal braces and percent signs. Unknown names are written as
+// %!name(MISSING). The first 1024 distinct templates are compiled once and
+// cached; later ones are compiled on every call.
+func Named(template string, args any) string {
+	p := newPrinter()
+	p.printNamed(compileNamed(template), args)
+	return p.takeString()
+}
+
+// AppendNamed is like Named but appends the result to b.
+func AppendNamed(b []byte, template string, args any) []byte {
+	p := newPrinter()
+	p.printNamed(compileNamed(template), args)
+	b = append(b, p.buf...)
+	p.free()
+	return b
+}
+
+func compileNamed(template string) *namedTemplate {
+	return namedCache.get(template, parseNamed)
+}
+
+// parseNamed splits template into named directives. Malformed placeholders
+// are kept as literal text.
+func parseNamed(template string) *namedTemplate {
+	t := &namedTemplate{}
+	var lit []byte
+	end := len(template)
+	for i := 0; i < end; {
+		c := template[i]
+		switch {
+		case (c == '{' || c == '}' || c == '%') && i+1 < end && template[i+1] == c:
+			lit = append(lit, c)
+			i += 2
+			continue
+		case c == '{':
+			if d, n, ok := parseBraceNamed(template[i:]); ok {
+				d.lit = string(lit)
+				lit = lit[:0]
+				t.directives = append(t.directives, d)
+				i += n
+				continue
+			}
+		case c == '%' && i+1 < end && template[i+1] == '(':
+			if d, n, ok := parsePercentNamed(template[i:]); ok {
+				d.lit = string(lit)
+				lit = lit[:0]
+				t.directives = append(t.directives, d)
+				i += n
+				continue
+			}
+		}
+		lit = append(lit, c)
+		i++
+	}
+	t.directives = append(t.directives, namedDirective{directive: directive{lit: string(lit)}})
+	return t
+}
+
+// parseBraceNamed parses {name} or {name:spec} at the start of s and returns
+// the directive and its length.
+func parseBraceNamed(s string) (namedDirective, int, bool) {
+	closing := strings.IndexByte(s, '}')
+	if closing < 0 {
+		return namedDirective{}, 0, false
+	}
+	name, spec, _ := strings.Cut(s[1:closing], ":")
+	if name == "" || strings.ContainsAny(name, "{%") {
+		return namedDirective{}, 0, false
+	}
+	flags, verb, ok := parseSpec(spec)
+	if !ok {
+		return namedDirective{}, 0, false
+	}
+	return namedDirective{directive: directive{flags: flags, verb: verb, hasArg: true}, name: name}, closing + 1, true
+}
+
+// parsePercentNamed parses %(name)spec at the start of s, where spec ends at
+// the verb, and returns the directive and its length.
+func parsePercentNamed(s string) (namedDirective, int, bool) {
+	closing := strings.IndexByte(s, ')')
+	if closing < 3 {
+		return namedDirective{}, 0, false
+	}
+	name := s[2:closing]
+	i := closing + 1
+	for i < len(s) && strings.IndexByte("#0+- '.123456789", s[i]) >= 0 {
+		i++
+	}
+	if i >= len(s) {
+		return namedDirective{}, 0, false
+	}
+	flags, verb, ok := parseSpec(s[closing+1 : i+1])
+	if !ok {
+		return namedDirective{}, 0, false
+	}
+	return namedDirective{directive: directive{flags: flags, verb: verb, hasArg: true}, name: name}, i + 1, true
+}
+
+// printNamed formats t, resolving each name in args.
+func (p *printer) printNamed(t *namedTemplate, args any) {
+	var m map[string]any
+	var v reflect.Value
+	switch a := args.(type) {
+	case map[string]any:
+		m = a
+	case nil:
+	default:
+		v = reflect.ValueOf(args)
+		for v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+	}
+
+	for i := range t.directives {
+		d := &t.directives[i]
+		if !d.hasArg {
+			p.printDirective(&d.directive, nil)
+			continue
+		}
+		var arg any
+		var ok bool
+		if m != nil {
+			arg, ok = m[d.name]
+		} else {
+			arg, ok = lookupNamed(v, d.name)
+		}
+		if !ok {
+			p.buf.writeString(d.lit)
+			p.buf.writeString(percentBangString)
+			p.buf.writeString(d.name)
+			p.buf.writeString(missingString)
+			continue
+		}
+		p.printDirective(&d.directive, arg)
+	}
+}
+
+// lookupNamed returns the value called name in a map with string keys or a
+// struct.
+func lookupNamed(v reflect.Value, name string) (any, bool) {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		e := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		if !e.IsValid() {
+			return nil, false
+		}
+		return e.Interface(), true
+	case reflect.Struct:
+		f := v.FieldByName(name)
+		if !f.IsValid() {
+			f = v.FieldByNameFunc(func(field string) bool { return strings.EqualFold(field, name) })
+		}
+		if !f.IsValid() || !f.CanInterface() {
+			return nil, false
+		}
+		return f.Interface(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,63 @@
+package xprint_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+type namedArgs struct {
+	User  string
+	Count int
+	Price float64
+}
+
+func TestNamed(t *testing.T) {
+	m := map[string]any{"user": "alice", "count": 3, "price": 4.5}
+	s := namedArgs{User: "bob", Count: 12, Price: 0.25}
+
+	testCases := []struct {
+		template string
+		args     any
+		expected string
+	}{
+		{"Hello {user}, you have {count:d} items", m, "Hello alice, you have 3 items"},
+		{"Hello {User}, you have {count:d} items", s, "Hello bob, you have 12 items"},
+		{"Hello {User}, you have {Count} items", &s, "Hello bob, you have 12 items"},
+		{"[{count:5d}] [{count:-5d}] [{count:05d}]", m, "[    3] [3    ] [00003]"},
+		{"{price:.2f} {count:x}", m, "4.50 3"},
+		{"%(user)s has %(count)d", m, "alice has 3"},
+		{"{{user}} 100%% {user}", m, "{user} 100% alice"},
+		{"{nope} left", m, "%!nope(MISSING) left"},
+		{"{unclosed", m, "{unclosed"},
+		{"{name}", map[string]string{"name": "carol"}, "carol"},
+		{"no placeholders", nil, "no placeholders"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.template, func(t *testing.T) {
+			if o := xprint.Named(tc.template, tc.args); o != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, o)
+			}
+		})
+	}
+
+	if o := string(xprint.AppendNamed([]byte("> "), "{user}", m)); o != "> alice" {
+		t.Errorf("Expected > alice, got %s", o)
+	}
+}
+
+// TestNamedManyTemplates uses more distinct templates than are cached.
+func TestNamedManyTemplates(t *testing.T) {
+	for round := range 2 {
+		for i := range 1500 {
+			template := strings.Repeat(".", i%7) + "{n}" + strconv.Itoa(i)
+			expected := strings.Repeat(".", i%7) + "x" + strconv.Itoa(i)
+			if o := xprint.Named(template, map[string]string{"n": "x"}); o != expected {
+				t.Fatalf("round %d: Expected %s, got %s", round, expected, o)
+			}
+		}
+	}
+}
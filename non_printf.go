@@ -37,71 +37,8 @@ func (p *printer) print(args []any) {
 				p.buf.writeString("false")
 			}
 			lastWasString = false
-		case int:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtInt()
-			lastWasString = false
-		case int8:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtInt8()
-			lastWasString = false
-		case int16:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtInt16()
-			lastWasString = false
-		case int32:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtInt32()
-			lastWasString = false
-		case int64:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtInt64()
-			lastWasString = false
-		case uint:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtUint()
-			lastWasString = false
-		case uint8:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtUint8()
-			lastWasString = false
-		case uint16:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtUint16()
-			lastWasString = false
-		case uint32:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtUint32()
-			lastWasString = false
-		case uint64:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtUint64()
-			lastWasString = false
-		case uintptr:
-			p.fmt.uintbase = 10
-			p.arg = v
-			p.verb = 'v'
-			p.fmtUintptr()
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+			p.printIntegerArg(v, 'v')
 			lastWasString = false
 		case float32:
 			p.printFloat32(v, 'v')
@@ -109,13 +46,20 @@ func (p *printer) print(args []any) {
 		case float64:
 			p.printFloat64(v, 'v')
 			lastWasString = false
-		case complex64, complex128:
-			p.printComplex(v, 'v')
+		case complex64:
+			p.printComplex(complex128(v), 'v', "complex64", reflect.Complex64)
+			lastWasString = false
+		case complex128:
+			p.printComplex(v, 'v', "complex128", reflect.Complex128)
 			lastWasString = false
 		case error:
 			p.buf.writeString(v.Error())
 			lastWasString = false
 		default:
+			if p.printRegistered(v, 'v') {
+				lastWasString = false
+				continue
+			}
 			// For any other type, use reflection
 			p.value = reflect.ValueOf(v)
 			if p.value.Kind() == reflect.Ptr {
@@ -133,6 +77,18 @@ func (p *printer) print(args []any) {
 	}
 }
 
+// println formats each operand like print, but always separates operands
+// with a space and terminates the output with a newline.
+func (p *printer) println(args []any) {
+	for i := range args {
+		if i > 0 {
+			p.buf.writeByte(' ')
+		}
+		p.print(args[i : i+1])
+	}
+	p.buf.writeByte('\n')
+}
+
 func printisNumeric(v any) bool {
 	switch v.(type) {
 	case int, int8, int16, int32, int64,
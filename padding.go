@@ -1 +1,67 @@
 package xprint
+
+// writePadded writes s padded with spaces to the directive's width,
+// honouring the minus flag.
+func (p *printer) writePadded(s string) {
+	width := 0
+	if p.fmt.widPresent {
+		width = p.fmt.wid - len(s)
+	}
+	if !p.fmt.minus {
+		p.writePadding(width, ' ')
+	}
+	p.buf.writeString(s)
+	if p.fmt.minus {
+		p.writePadding(width, ' ')
+	}
+}
+
+// writeNumber writes the decimal number s, adding the sign requested by the
+// plus and space flags and padding to the directive's width. Zero padding goes
+// between the sign and the digits.
+func (p *printer) writeNumber(s string) {
+	var sign byte
+	switch {
+	case len(s) > 0 && (s[0] == '-' || s[0] == '+' || s[0] == ' '):
+		sign, s = s[0], s[1:]
+	case p.fmt.plus:
+		sign = '+'
+	case p.fmt.space:
+		sign = ' '
+	}
+	n := len(s)
+	if sign != 0 {
+		n++
+	}
+	width := 0
+	if p.fmt.widPresent {
+		width = p.fmt.wid - n
+	}
+	switch {
+	case p.fmt.minus:
+		if sign != 0 {
+			p.buf.writeByte(sign)
+		}
+		p.buf.writeString(s)
+		p.writePadding(width, ' ')
+	case p.fmt.zero:
+		if sign != 0 {
+			p.buf.writeByte(sign)
+		}
+		p.writePadding(width, '0')
+		p.buf.writeString(s)
+	default:
+		p.writePadding(width, ' ')
+		if sign != 0 {
+			p.buf.writeByte(sign)
+		}
+		p.buf.writeString(s)
+	}
+}
+
+// writePadding writes n copies of c; n <= 0 writes nothing.
+func (p *printer) writePadding(n int, c byte) {
+	for range n {
+		p.buf.writeByte(c)
+	}
+}
@@ -0,0 +1,245 @@
+package xprint
+
+import (
+	stdfmt "fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	reflect "github.com/goccy/go-reflect"
+)
+
+// typePlan is the formatting plan of a type. printValue consults it instead
+// of re-inspecting the type for every value, which matters when the same
+// struct or slice type is printed many times.
+type typePlan struct {
+	gen  uint64 // cacheGeneration the plan was built under
+	name string // Type.String(), written by %#v
+	// hooks is set when a registered formatter, Appender, TextAppender,
+	// well-known type or fmt.Formatter may take over the value.
+	hooks     bool
+	appender  appenderKind
+	formatter bool // pointer type with a Format method or *big.Rat
+	bytes     bool // slice of bytes
+
+	// Slices and arrays
+	elemKind   reflect.Kind
+	elemSize   uintptr
+	elemDirect bool
+
+	// Structs
+	fields []fieldPlan
+}
+
+// fieldPlan is the plan of one struct field.
+type fieldPlan struct {
+	name   string
+	offset uintptr
+	kind   reflect.Kind
+	action fieldAction
+	// direct fields hold a bool, number or string without methods and are
+	// read straight from memory.
+	direct bool
+}
+
+var (
+	// planCache maps reflect.Type to *typePlan.
+	planCache sync.Map
+
+	// cacheGeneration counts changes to the registered type formatters and
+	// the redaction predicate, which plans and field actions depend on.
+	// Cached entries of an older generation are rebuilt, so a plan built
+	// concurrently with a change cannot outlive it.
+	cacheGeneration atomic.Uint64
+
+	// plainPlan stands in for the plan of plain types, see isPlain.
+	plainPlan = &typePlan{}
+
+	formatterType = reflect.TypeOf((*stdfmt.Formatter)(nil)).Elem()
+	ratPtrType    = reflect.TypeOf((*big.Rat)(nil))
+)
+
+// planOf returns the plan of t, computing it on first use and after the
+// registries it depends on change.
+func planOf(t reflect.Type) *typePlan {
+	gen := cacheGeneration.Load()
+	if cached, ok := planCache.Load(t); ok {
+		if plan := cached.(*typePlan); plan.gen == gen { //nolint:forcetypeassert // cache only holds *typePlan
+			return plan
+		}
+	}
+
+	plan := &typePlan{gen: gen, name: t.String(), hooks: hasHooks(t), appender: appenderKindOf(t)}
+	switch t.Kind() {
+	case reflect.Ptr:
+		plan.formatter = t == ratPtrType || t.Implements(formatterType)
+	case reflect.Slice, reflect.Array:
+		elem := t.Elem()
+		plan.bytes = t.Kind() == reflect.Slice && elem.Kind() == reflect.Uint8
+		plan.elemKind = elem.Kind()
+		plan.elemSize = elem.Size()
+		plan.elemDirect = isDirect(elem)
+	case reflect.Struct:
+		sf := fieldsOf(t)
+		plan.fields = make([]fieldPlan, t.NumField())
+		for i := range plan.fields {
+			field := t.Field(i)
+			action := sf.action(i)
+			plan.fields[i] = fieldPlan{
+				name:   field.Name,
+				offset: field.Offset,
+				kind:   field.Type.Kind(),
+				action: action,
+				direct: action == fieldShow && isDirect(field.Type),
+			}
+		}
+	}
+
+	planCache.Store(t, plan)
+	return plan
+}
+
+// hasHooks reports whether one of the method or registry paths of printValue
+// may apply to values of type t.
+func hasHooks(t reflect.Type) bool {
+	if table := typeFormatters.Load(); table != nil {
+		if _, ok := (*table)[t]; ok {
+			return true
+		}
+	}
+	switch t {
+	case timeType, durationType, addrType, addrPortType, prefixType, ratPtrType:
+		return true
+	}
+	return appenderKindOf(t) != appenderNone || t.Kind() == reflect.Ptr && t.Implements(formatterType)
+}
+
+// isPlain reports whether v is a bool, number, string or interface whose
+// type has no methods. No hook applies to those while no type formatters are
+// registered, so printValue skips their plan lookup outside %#v.
+func isPlain(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Bool, reflect.String, reflect.Interface,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return v.Type().NumMethod() == 0 && typeFormatters.Load() == nil
+	}
+	return false
+}
+
+// isDirect reports whether values of type t are plain bools, numbers or
+// strings that printDirect can format from memory.
+func isDirect(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return !hasHooks(t)
+	}
+	return false
+}
+
+// printDirect formats the value of kind k stored at ptr exactly as printValue
+// formats it without %#v. It reports false, writing nothing, for integers
+// under verbs they do not support, leaving the error message to printValue.
+func (p *printer) printDirect(ptr unsafe.Pointer, k reflect.Kind, verb rune) bool {
+	var u uint64
+	var negative, signed bool
+	switch k {
+	case reflect.Bool:
+		p.fmt.fmtBool(*(*bool)(ptr))
+		return true
+	case reflect.String:
+		p.fmt.fmtString(*(*string)(ptr))
+		return true
+	case reflect.Float32:
+		p.printFloat32(*(*float32)(ptr), verb)
+		return true
+	case reflect.Float64:
+		p.printFloat64(*(*float64)(ptr), verb)
+		return true
+	case reflect.Int:
+		u, negative, signed = splitInt(*(*int)(ptr))
+	case reflect.Int8:
+		u, negative, signed = splitInt(*(*int8)(ptr))
+	case reflect.Int16:
+		u, negative, signed = splitInt(*(*int16)(ptr))
+	case reflect.Int32:
+		u, negative, signed = splitInt(*(*int32)(ptr))
+	case reflect.Int64:
+		u, negative, signed = splitInt(*(*int64)(ptr))
+	case reflect.Uint:
+		u = uint64(*(*uint)(ptr))
+	case reflect.Uint8:
+		u = uint64(*(*uint8)(ptr))
+	case reflect.Uint16:
+		u = uint64(*(*uint16)(ptr))
+	case reflect.Uint32:
+		u = uint64(*(*uint32)(ptr))
+	case reflect.Uint64:
+		u = *(*uint64)(ptr)
+	}
+	return p.fmtInteger(u, negative, signed, verb)
+}
+
+// printStruct prints the fields of struct v following plan. Fields of an
+// addressable struct that need no formatting decisions are read directly.
+func (p *printer) printStruct(v reflect.Value, plan *typePlan, verb rune, prec int) {
+	var base unsafe.Pointer
+	if v.CanAddr() && !p.fmt.sharpV {
+		base = reflect.ToReflectValue(v).Addr().UnsafePointer()
+	}
+	p.buf.writeByte('{')
+	first := true
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		if f.action == fieldOmit {
+			continue
+		}
+		if !first {
+			p.buf.writeByte(' ')
+		}
+		first = false
+		if p.fmt.plusV {
+			p.buf.writeString(f.name)
+			p.buf.writeByte(':')
+		}
+		switch {
+		case f.action == fieldRedact:
+			p.buf.writeString(redactedString)
+		case f.direct && base != nil && p.printDirect(unsafe.Add(base, f.offset), f.kind, verb):
+		default:
+			p.printValue(v.Field(i), verb, prec)
+		}
+	}
+	p.buf.writeByte('}')
+}
+
+// printElems prints the elements of slice or array v between brackets.
+// Slice elements are always addressable, so plain elements are read
+// directly.
+func (p *printer) printElems(v reflect.Value, plan *typePlan, verb rune, prec int) {
+	var base unsafe.Pointer
+	if plan.elemDirect && !p.fmt.sharpV {
+		switch {
+		case v.Kind() == reflect.Slice:
+			base = reflect.ToReflectValue(v).UnsafePointer()
+		case v.CanAddr():
+			base = reflect.ToReflectValue(v).Addr().UnsafePointer()
+		}
+	}
+	p.buf.writeByte('[')
+	for i := range v.Len() {
+		if i > 0 {
+			p.buf.writeByte(' ')
+		}
+		if base != nil && p.printDirect(unsafe.Add(base, uintptr(i)*plan.elemSize), plan.elemKind, verb) {
+			continue
+		}
+		p.printValue(v.Index(i), verb, prec)
+	}
+	p.buf.writeByte(']')
+}
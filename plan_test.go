@@ -0,0 +1,79 @@
+package xprint_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+type planNode struct {
+	Next  *planNode
+	ID    int32
+	Name  string
+	Score float64
+	OK    bool
+	small uint8
+	Tags  []uint16
+	Grid  [2]int
+}
+
+type planCelsius int
+
+// TestPlanMatchesFmt prints values whose fields are read through the cached
+// type plan, both directly addressable and behind pointers.
+func TestPlanMatchesFmt(t *testing.T) {
+	leaf := &planNode{ID: -7, Name: "leaf", Score: 0.25, small: 200, Tags: []uint16{1, 65535}, Grid: [2]int{-1, 1}}
+	root := planNode{ID: 1, Name: "root", Score: 1e21, OK: true, Tags: []uint16{}}
+
+	testCases := []any{
+		root,
+		*leaf,
+		[]planNode{root, *leaf},
+		[]int64{-1, 0, 1 << 40},
+		[3]float32{0.5, -2, 3e-9},
+		[]string{"a", "", "c d"},
+		struct{ A, B planCelsius }{-3, 4},
+	}
+	for _, arg := range testCases {
+		for _, format := range []string{"%v", "%+v"} {
+			if expected, got := fmt.Sprintf(format, arg), xprint.Printf(format, arg); got != expected {
+				t.Errorf("%s of %T: expected %q, got %q", format, arg, expected, got)
+			}
+		}
+	}
+
+	if o := xprint.Printf("%x %d", []int64{-1, 255}, [2]uint8{7, 8}); o != "[-1 ff] [7 8]" {
+		t.Errorf("Expected [-1 ff] [7 8], got %s", o)
+	}
+
+	// Fields reached through a pointer are addressable
+	root.Next = leaf
+	if o := xprint.Printf("%+v", root.Next); o != fmt.Sprintf("%+v", root.Next) {
+		t.Errorf("Expected %s, got %s", fmt.Sprintf("%+v", root.Next), o)
+	}
+}
+
+// TestPlanInvalidation checks that registering a formatter or a redaction
+// predicate after a type was printed takes effect.
+func TestPlanInvalidation(t *testing.T) {
+	v := struct {
+		Temp  planCelsius
+		Token string
+	}{21, "abc"}
+	if o := xprint.Printf("%v", v); o != "{21 abc}" {
+		t.Fatalf("Expected {21 abc}, got %s", o)
+	}
+
+	xprint.RegisterType(func(dst []byte, c planCelsius, _ rune) []byte {
+		return append(strconv.AppendInt(dst, int64(c), 10), "°C"...)
+	})
+	defer xprint.UnregisterType[planCelsius]()
+	xprint.SetRedactFunc(func(name string) bool { return name == "Token" })
+	defer xprint.SetRedactFunc(nil)
+
+	if o := xprint.Printf("%v", v); o != "{21°C [REDACTED]}" {
+		t.Errorf("Expected {21°C [REDACTED]}, got %s", o)
+	}
+}
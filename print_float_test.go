@@ -0,0 +1,64 @@
+package xprint_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+type celsius float32
+
+// TestFloatsMatchFmt runs floats and complex numbers through the flags,
+// widths, precisions and verbs fmt supports for them.
+func TestFloatsMatchFmt(t *testing.T) {
+	values := []any{
+		0.0, math.Copysign(0, -1), 1.5, -2.25, 123456.789, 1e6, 1e21, 1e-7,
+		float32(0.1), float32(-3.25), celsius(21.5),
+		math.Inf(1), math.Inf(-1), math.NaN(),
+		complex(1.5, -2), complex64(complex(-1, 0.5)),
+	}
+	formats := []string{
+		"%v", "%+v", "% v", "%8v|", "%-8v|", "%08v",
+		"%f", "%.2f", "%+.2f", "% .1f", "%9.3f", "%-9.3f|", "%09.3f", "%+09.3f", "%.0f", "%#.0f",
+		"%F", "%.2F", "%e", "%.2e", "%E", "%+.1e", "%#.0e",
+		"%g", "%.3g", "%#g", "%#.3g", "%G", "%.2v",
+		"%x", "%.3x", "%#x", "%X", "%b",
+		"%d", "%s",
+	}
+	for _, format := range formats {
+		for _, v := range values {
+			if expected, got := fmt.Sprintf(format, v), xprint.Printf(format, v); got != expected {
+				t.Errorf("%s of %T(%v): expected %q, got %q", format, v, v, expected, got)
+			}
+		}
+	}
+
+	nested := struct {
+		A float64
+		B []float32
+		C complex128
+	}{-1.5, []float32{0.25, 3}, complex(0, 2)}
+	for _, format := range []string{"%v", "%+v", "%.1f", "%6.2f", "%d"} {
+		if expected, got := fmt.Sprintf(format, nested), xprint.Printf(format, nested); got != expected {
+			t.Errorf("%s of %T: expected %q, got %q", format, nested, expected, got)
+		}
+	}
+}
+
+func TestFloatAllocs(t *testing.T) {
+	buf := make([]byte, 0, 128)
+	for _, format := range []string{"%v", "%f", "%.2f", "%+9.3f", "%-8.1e|", "%08.3f", "%g", "%#g", "%x"} {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = xprint.Appendf(buf[:0], format, 3.14159)
+		})
+		if allocs > 0 {
+			t.Errorf("%s of a float64: expected no allocations, got %v", format, allocs)
+		}
+	}
+	c := complex(1.5, -2)
+	if allocs := testing.AllocsPerRun(100, func() { buf = xprint.Appendf(buf[:0], "%.2f", c) }); allocs > 1 {
+		t.Errorf("%%.2f of a complex128: expected at most 1 allocation, got %v", allocs)
+	}
+}
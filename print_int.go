@@ -2,1722 +2,376 @@ package xprint
 
 import (
 	"strconv"
+	"unicode/utf8"
+
+	reflect "github.com/goccy/go-reflect"
 )
 
 const (
-	// digitBits is used in writeDecimalInt
-	digitBits = 28
+	ldigits = "0123456789abcdefx"
+	udigits = "0123456789ABCDEFX"
+
+	// smallsString holds the two-digit decimal forms of 0 through 99.
+	smallsString = "00010203040506070809" +
+		"10111213141516171819" +
+		"20212223242526272829" +
+		"30313233343536373839" +
+		"40414243444546474849" +
+		"50515253545556575859" +
+		"60616263646566676869" +
+		"70717273747576777879" +
+		"80818283848586878889" +
+		"90919293949596979899"
 )
 
-// Constants for string concatenation
-var (
-	smallIntsTable = []string{
-		"0", "1", "2", "3", "4", "5", "6", "7", "8", "9",
-		"10", "11", "12", "13", "14", "15", "16", "17", "18", "19",
-		"20", "21", "22", "23", "24", "25", "26", "27", "28", "29",
-		"30", "31", "32", "33", "34", "35", "36", "37", "38", "39",
-		"40", "41", "42", "43", "44", "45", "46", "47", "48", "49",
-		"50", "51", "52", "53", "54", "55", "56", "57", "58", "59",
-		"60", "61", "62", "63", "64", "65", "66", "67", "68", "69",
-		"70", "71", "72", "73", "74", "75", "76", "77", "78", "79",
-		"80", "81", "82", "83", "84", "85", "86", "87", "88", "89",
-		"90", "91", "92", "93", "94", "95", "96", "97", "98", "99",
-	}
-
-	digits      = []byte("0123456789abcdef")
-	digitsUpper = []byte("0123456789ABCDEF")
-)
+// integer is the set of types formatted by the integer core.
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
 
-// printInt formats signed and unsigned integers.
-// IGNORE THIS!
-func (p *printer) printInt(v any, verb rune) {
-	p.arg = v
-	p.verb = verb
-	if p.fmt.uintbase == 0 {
-		p.fmt.uintbase = 10
+// splitInt returns the magnitude of v and whether it is negative. The
+// magnitude of the most negative value of a signed type still fits.
+func splitInt[T integer](v T) (u uint64, negative, signed bool) {
+	signed = ^T(0) < 0
+	if signed && v < 0 {
+		return -uint64(int64(v)), true, true
 	}
+	return uint64(v), false, signed
+}
 
-	switch v.(type) {
+// printIntegerArg formats arg if it is one of the predeclared integer types,
+// printing fmt's %!verb(type=value) for verbs integers do not support.
+func (p *printer) printIntegerArg(arg any, verb rune) bool {
+	var u uint64
+	var negative, signed bool
+	switch v := arg.(type) {
 	case int:
-		p.fmtInt()
+		u, negative, signed = splitInt(v)
 	case int8:
-		p.fmtInt8()
+		u, negative, signed = splitInt(v)
 	case int16:
-		p.fmtInt16()
+		u, negative, signed = splitInt(v)
 	case int32:
-		p.fmtInt32()
+		u, negative, signed = splitInt(v)
 	case int64:
-		p.fmtInt64()
+		u, negative, signed = splitInt(v)
 	case uint:
-		p.fmtUint()
+		u = uint64(v)
 	case uint8:
-		p.fmtUint8()
+		u = uint64(v)
 	case uint16:
-		p.fmtUint16()
+		u = uint64(v)
 	case uint32:
-		p.fmtUint32()
+		u = uint64(v)
 	case uint64:
-		p.fmtUint64()
+		u = v
 	case uintptr:
-		p.fmtUintptr()
+		u = uint64(v)
 	default:
-		p.buf.writeString(percentBangString)
-		p.buf.writeByte(byte(verb))
-		p.buf.writeString(badVerbString)
-		return
+		return false
+	}
+	if !p.fmtInteger(u, negative, signed, verb) {
+		p.badIntVerb(verb, reflect.TypeOf(arg), u, negative)
 	}
+	return true
 }
 
-// fmtInteger formats signed and unsigned integers - based directly on fmt's implementation
-func (p *printer) fmtInteger() {
-	v := p.arg.(int)
-	base := p.fmt.uintbase
-
-	// Fast path for small integers in base 10
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallIntsMap[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
+// printIntValue formats an integer reached by reflection.
+func (p *printer) printIntValue(v reflect.Value, verb rune) {
+	var u uint64
+	var negative, signed bool
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		u, negative, signed = splitInt(v.Int())
+	default:
+		u = v.Uint()
 	}
+	if !p.fmtInteger(u, negative, signed, verb) {
+		p.badIntVerb(verb, v.Type(), u, negative)
+	}
+}
 
-	negative := v < 0
-
-	// Use the global precomputed digits directly
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
+// fmtInteger formats the integer with magnitude u for verb as fmt does:
+// precision or the 0 flag add leading zeros, # adds the 0b, 0 or 0x prefix,
+// %O always adds 0o, + and space mark non-negative numbers, and the width pads
+// with spaces. It reports false, writing nothing, for verbs integers do not
+// support. Grouped or localized numbers take a separate pass.
+func (p *printer) fmtInteger(u uint64, negative, signed bool, verb rune) bool {
+	if p.localizes() && verb != 'c' && verb != 'q' && verb != 'U' {
+		return p.printLocalized(func() bool { return p.fmtInteger(u, negative, signed, verb) })
+	}
+	f := &p.fmt
+	base, digits, sharp := 10, ldigits, f.sharp
+	switch verb {
+	case 'v':
+		if f.sharpV && !signed {
+			base, sharp = 16, true
+		}
+	case 'd':
+	case 'b', 'B':
+		base = 2
+	case 'o', 'O':
+		base = 8
+	case 'x':
+		base = 16
+	case 'X':
+		base, digits = 16, udigits
+	case 'c', 'q', 'U':
+		p.fmtIntegerChar(u, negative, verb)
+		return true
+	default:
+		return false
 	}
 
-	// Handle special case for zero
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		// Precision of 0 and value of 0 means "print nothing" but padding.
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		// Handle padding
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			// Space padding
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
+	// The common case of %d and %v without flags
+	if base == 10 && !f.widPresent && !f.precPresent && !f.plus && !f.space {
+		if negative {
+			p.buf.writeByte('-')
 		}
-		p.fmt.zero = oldZero
-		return
+		p.buf = appendDecimal(p.buf, u)
+		return true
 	}
 
-	// Format into a buffer; we'll move it into p.buf later.
-	// Allow enough space for the maximum number of digits,
-	// a sign, 0x prefix, and potentially a blank or + or - sign
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
+	// Precision 0 and value 0 mean "print nothing" but padding
+	if f.precPresent && f.prec == 0 && u == 0 {
+		if f.widPresent {
+			p.writePadding(f.wid, ' ')
+		}
+		return true
+	}
 
-	// Two ways to ask for extra leading zero digits: %.3d or %03d.
-	// If both are specified the f.zero flag is ignored and
-	// padding with spaces is used instead.
+	// Two ways to ask for extra leading zero digits: %.3d or %03d. If both
+	// are given the 0 flag is ignored and the width pads with spaces.
 	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if negative || p.fmt.plus || p.fmt.space {
-			prec-- // leave room for sign
+	if f.precPresent {
+		prec = f.prec
+	} else if f.zero && !f.minus && f.widPresent {
+		prec = f.wid
+		if negative || f.plus || f.space {
+			prec-- // leave room for the sign
 		}
 	}
 
-	// Because printing is easier right-to-left: format u into buf, ending at buf[i].
-	i := len(buf)
-
-	absV := v
-	if negative {
-		absV = -v
+	// The digits, zeros, a 0o0 prefix and the sign are formed right to left
+	buf := f.intbuf[:]
+	if prec+4 > len(buf) {
+		buf = make([]byte, prec+4)
 	}
-
-	// Use constants for the division and modulo for more efficient code.
-	// Switch cases ordered by popularity.
+	i := len(buf)
 	switch base {
 	case 10:
-		for absV >= 10 {
-			i--
-			next := absV / 10
-			buf[i] = byte('0' + absV - next*10)
-			absV = next
-		}
+		i = formatDecimal(buf, u)
 	case 16:
-		for absV >= 16 {
+		for ; u >= 16; u >>= 4 {
 			i--
-			buf[i] = digitsByte[absV&0xF]
-			absV >>= 4
+			buf[i] = digits[u&0xF]
 		}
+		i--
+		buf[i] = digits[u]
 	case 8:
-		for absV >= 8 {
+		for ; u >= 8; u >>= 3 {
 			i--
-			buf[i] = byte('0' + absV&7)
-			absV >>= 3
+			buf[i] = byte('0' + u&7)
 		}
+		i--
+		buf[i] = byte('0' + u)
 	case 2:
-		for absV >= 2 {
+		for ; u >= 2; u >>= 1 {
 			i--
-			buf[i] = byte('0' + absV&1)
-			absV >>= 1
-		}
-	default:
-		// Unsupported base; shouldn't happen, but handle it just in case
-		if negative {
-			p.buf.writeString("-" + strconv.FormatUint(uint64(-v), base))
-		} else {
-			p.buf.writeString(strconv.FormatUint(uint64(v), base))
+			buf[i] = byte('0' + u&1)
 		}
-		return
+		i--
+		buf[i] = byte('0' + u)
 	}
-
-	i--
-	buf[i] = digitsByte[absV]
-
-	// Add leading zeros for precision, if requested and needed
 	for i > 0 && prec > len(buf)-i {
 		i--
 		buf[i] = '0'
 	}
 
-	// Add prefix for base, if needed
-	if p.fmt.sharp {
+	if sharp {
 		switch base {
 		case 2:
-			// Add a leading 0b.
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
+			i -= 2
+			buf[i], buf[i+1] = '0', 'b'
 		case 8:
 			if buf[i] != '0' {
 				i--
 				buf[i] = '0'
 			}
 		case 16:
-			// Add a leading 0x or 0X.
-			i--
-			buf[i] = digitsByte[16] // 'x' or 'X'
-			i--
-			buf[i] = '0'
+			i -= 2
+			buf[i], buf[i+1] = '0', digits[16]
 		}
 	}
+	if verb == 'O' {
+		i -= 2
+		buf[i], buf[i+1] = '0', 'o'
+	}
 
-	// Add sign for signed integers
-	if negative {
+	switch {
+	case negative:
 		i--
 		buf[i] = '-'
-	} else if p.fmt.plus {
+	case f.plus:
 		i--
 		buf[i] = '+'
-	} else if p.fmt.space {
+	case f.space:
 		i--
 		buf[i] = ' '
 	}
 
-	// Handle width padding
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			// Left padding
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		// Append the formatted number
+	if !f.widPresent || f.wid <= len(buf)-i {
 		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			// Right padding
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
 	} else {
-		// No padding needed
-		p.buf.write(buf[i:])
+		p.padNumber(buf[i:])
 	}
+	return true
 }
 
-func (p *printer) fmtUint64() {
-	v := p.arg.(uint64)
-	base := p.fmt.uintbase
-
-	// Fast path for small uint64 in base 10
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallUint64Map[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
-	}
-
-	// Use the global precomputed digits directly
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
-	}
-
-	// Handle special case for zero
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		// Precision of 0 and value of 0 means "print nothing" but padding.
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		// Handle padding
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			// Space padding
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
+// padNumber writes the formatted number b padded with spaces to the width,
+// which must exceed len(b).
+func (p *printer) padNumber(b []byte) {
+	f := &p.fmt
+	if f.minus {
+		p.buf.write(b)
+		p.writePadding(f.wid-len(b), ' ')
 		return
 	}
+	p.writePadding(f.wid-len(b), ' ')
+	p.buf.write(b)
+}
 
-	// Format into a buffer; we'll move it into p.buf later.
-	// Allow enough space for the maximum number of digits,
-	// a sign, 0x prefix, and potentially a blank or + or - sign
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-
-	// Two ways to ask for extra leading zero digits: %.3d or %03d.
-	// If both are specified the f.zero flag is ignored and
-	// padding with spaces is used instead.
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if p.fmt.plus || p.fmt.space {
-			prec-- // leave room for sign
-		}
-	}
-
-	// Because printing is easier right-to-left: format u into buf, ending at buf[i].
-	i := len(buf)
-	u := v // make a copy of v to work with
+// fmtIntegerChar formats the integer for %c, %q or %U. Characters are formed
+// from the two's complement bits like fmt does.
+func (p *printer) fmtIntegerChar(u uint64, negative bool, verb rune) {
+	if negative {
+		u = -u
+	}
+	start := len(p.buf)
+	switch verb {
+	case 'c':
+		p.buf = utf8.AppendRune(p.buf, runeOf(u))
+	case 'q':
+		p.fmtQc(u)
+	case 'U':
+		p.fmtUnicode(u)
+	}
+	p.padRunesFrom(start, verb != 'U')
+}
 
-	// Use constants for the division and modulo for more efficient code.
-	// Switch cases ordered by popularity.
-	switch base {
-	case 10:
-		for u >= 10 {
-			i--
-			next := u / 10
-			buf[i] = byte('0' + u - next*10)
-			u = next
-		}
-	case 16:
-		for u >= 16 {
-			i--
-			buf[i] = digitsByte[u&0xF]
-			u >>= 4
-		}
-	case 8:
-		for u >= 8 {
-			i--
-			buf[i] = byte('0' + u&7)
-			u >>= 3
-		}
-	case 2:
-		for u >= 2 {
-			i--
-			buf[i] = byte('0' + u&1)
-			u >>= 1
-		}
-	default:
-		// Unsupported base; shouldn't happen, but handle it just in case
-		p.buf.writeString(strconv.FormatUint(v, base))
+// padRunesFrom pads the characters written since start to the directive's
+// width, counting runes like fmt does for %c, %q and %U. The 0 flag pads with
+// zeros if zero is set.
+func (p *printer) padRunesFrom(start int, zero bool) {
+	if !p.fmt.widPresent {
 		return
 	}
-
-	i--
-	buf[i] = digitsByte[u]
-
-	// Add leading zeros for precision, if requested and needed
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
-	}
-
-	// Add prefix for base, if needed
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			// Add a leading 0b.
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			// Add a leading 0x or 0X.
-			i--
-			buf[i] = digitsByte[16] // 'x' or 'X'
-			i--
-			buf[i] = '0'
-		}
+	pad := p.fmt.wid - utf8.RuneCount(p.buf[start:])
+	switch {
+	case p.fmt.minus:
+		p.writePadding(pad, ' ')
+	case p.fmt.zero && zero:
+		p.padBefore(start, pad, '0')
+	default:
+		p.padBefore(start, pad, ' ')
 	}
+}
 
-	// Add sign for unsigned integers (only if requested with + or space)
-	if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
-		i--
-		buf[i] = ' '
+// appendDecimal appends the decimal digits of u.
+func appendDecimal(dst []byte, u uint64) []byte {
+	if u < 10 {
+		return append(dst, byte('0'+u))
 	}
-
-	// Handle width padding
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			// Left padding
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		// Append the formatted number
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			// Right padding
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		// No padding needed
-		p.buf.write(buf[i:])
+	if u < 100 {
+		return append(dst, smallsString[u*2:u*2+2]...)
 	}
+	var buf [20]byte
+	i := formatDecimal(buf[:], u)
+	return append(dst, buf[i:]...)
 }
 
-// Signed integer formatting functions
-
-func (p *printer) fmtInt() {
-	v := p.arg.(int)
-	base := p.fmt.uintbase
-	// Fast path for small integers in base 10.
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		if str, ok := smallIntsMap[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
-	}
-	negative := v < 0
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
-	}
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
-		return
-	}
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if negative || p.fmt.plus || p.fmt.space {
-			prec--
-		}
-	}
+// formatDecimal writes the decimal digits of u, two at a time, to the end of
+// buf and returns the index of the first digit.
+func formatDecimal(buf []byte, u uint64) int {
 	i := len(buf)
-	absV := v
-	if negative {
-		absV = -v
-	}
-	switch base {
-	case 10:
-		for absV >= 10 {
-			i--
-			next := absV / 10
-			buf[i] = byte('0' + absV - next*10)
-			absV = next
-		}
-	case 16:
-		for absV >= 16 {
-			i--
-			buf[i] = digitsByte[absV&0xF]
-			absV /= 16
-		}
-	case 8:
-		for absV >= 8 {
-			i--
-			buf[i] = byte('0' + absV&7)
-			absV /= 8
-		}
-	case 2:
-		for absV >= 2 {
-			i--
-			buf[i] = byte('0' + absV&1)
-			absV /= 2
-		}
-	default:
-		if negative {
-			p.buf.writeString("-" + strconv.FormatUint(uint64(-v), base))
-		} else {
-			p.buf.writeString(strconv.FormatUint(uint64(v), base))
-		}
-		return
-	}
+	for u >= 100 {
+		is := u % 100 * 2
+		u /= 100
+		i -= 2
+		buf[i+1] = smallsString[is+1]
+		buf[i] = smallsString[is]
+	}
+	is := u * 2
 	i--
-	buf[i] = digitsByte[absV]
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
-	}
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			i--
-			buf[i] = digitsByte[16] // 'x' or 'X'
-			i--
-			buf[i] = '0'
-		}
-	}
-	if negative {
-		i--
-		buf[i] = '-'
-	} else if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
+	buf[i] = smallsString[is+1]
+	if u >= 10 {
 		i--
-		buf[i] = ' '
-	}
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		p.buf.write(buf[i:])
+		buf[i] = smallsString[is]
 	}
+	return i
 }
 
-func (p *printer) fmtInt8() {
-	v := p.arg.(int8)
-	base := p.fmt.uintbase
-
-	// Fast path for small uint64 in base 10
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallInt8Map[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
+// runeOf converts the integer bits c to a rune, replacing values beyond
+// utf8.MaxRune with U+FFFD.
+func runeOf(c uint64) rune {
+	if c > utf8.MaxRune {
+		return utf8.RuneError
 	}
+	return rune(c)
+}
 
-	negative := v < 0
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
+// fmtQc writes a single-quoted character literal, escaped like
+// strconv.QuoteRune. The + flag escapes non-ASCII characters.
+func (p *printer) fmtQc(c uint64) {
+	r := runeOf(c)
+	if p.fmt.plus {
+		p.buf = strconv.AppendQuoteRuneToASCII(p.buf, r)
 	} else {
-		digitsByte = digits
-	}
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
-		return
-	}
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if negative || p.fmt.plus || p.fmt.space {
-			prec--
-		}
+		p.buf = strconv.AppendQuoteRune(p.buf, r)
 	}
+}
+
+// fmtUnicode writes c in Unicode format, as in "U+0078", followed by the
+// quoted character when the # flag is set and it is printable. Precision sets
+// the minimum number of hex digits.
+func (p *printer) fmtUnicode(c uint64) {
+	var buf [16]byte
 	i := len(buf)
-	absV := v
-	if negative {
-		absV = -v
-	}
-	switch base {
-	case 10:
-		for absV >= 10 {
-			i--
-			next := absV / 10
-			buf[i] = byte('0' + absV - next*10)
-			absV = next
-		}
-	case 16:
-		for absV >= 16 {
-			i--
-			buf[i] = digitsByte[absV&0xF]
-			absV /= 16
-		}
-	case 8:
-		for absV >= 8 {
-			i--
-			buf[i] = byte('0' + absV&7)
-			absV /= 8
-		}
-	case 2:
-		for absV >= 2 {
-			i--
-			buf[i] = byte('0' + absV&1)
-			absV /= 2
-		}
-	default:
-		if negative {
-			p.buf.writeString("-" + strconv.FormatUint(uint64(-v), base))
-		} else {
-			p.buf.writeString(strconv.FormatUint(uint64(v), base))
-		}
-		return
+	u := c
+	for u >= 16 {
+		i--
+		buf[i] = udigits[u&0xF]
+		u >>= 4
 	}
 	i--
-	buf[i] = digitsByte[absV]
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
+	buf[i] = udigits[u]
+
+	p.buf.writeString("U+")
+	prec := 4
+	if p.fmt.precPresent && p.fmt.prec > 4 {
+		prec = p.fmt.prec
 	}
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			i--
-			buf[i] = digitsByte[16]
-			i--
-			buf[i] = '0'
-		}
+	p.writePadding(prec-(len(buf)-i), '0')
+	p.buf.write(buf[i:])
+
+	if r := runeOf(c); p.fmt.sharp && c <= utf8.MaxRune && strconv.IsPrint(r) {
+		p.buf.writeString(" '")
+		p.buf.writeRune(r)
+		p.buf.writeByte('\'')
 	}
+}
+
+// badIntVerb writes fmt's %!verb(type=value) for an integer operand.
+func (p *printer) badIntVerb(verb rune, typ reflect.Type, u uint64, negative bool) {
+	p.buf.writeString(percentBangString)
+	p.buf.writeRune(verb)
+	p.buf.writeByte('(')
+	p.buf.writeString(typ.String())
+	p.buf.writeByte('=')
 	if negative {
-		i--
-		buf[i] = '-'
-	} else if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
-		i--
-		buf[i] = ' '
-	}
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		p.buf.write(buf[i:])
-	}
-}
-
-func (p *printer) fmtInt16() {
-	v := p.arg.(int16)
-	base := p.fmt.uintbase
-
-	// Fast path for small uint64 in base 10
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallInt16Map[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
-	}
-	negative := v < 0
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
-	}
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
-		return
-	}
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if negative || p.fmt.plus || p.fmt.space {
-			prec--
-		}
-	}
-	i := len(buf)
-	absV := v
-	if negative {
-		absV = -v
-	}
-	switch base {
-	case 10:
-		for absV >= 10 {
-			i--
-			next := absV / 10
-			buf[i] = byte('0' + absV - next*10)
-			absV = next
-		}
-	case 16:
-		for absV >= 16 {
-			i--
-			buf[i] = digitsByte[absV&0xF]
-			absV /= 16
-		}
-	case 8:
-		for absV >= 8 {
-			i--
-			buf[i] = byte('0' + absV&7)
-			absV /= 8
-		}
-	case 2:
-		for absV >= 2 {
-			i--
-			buf[i] = byte('0' + absV&1)
-			absV /= 2
-		}
-	default:
-		if negative {
-			p.buf.writeString("-" + strconv.FormatUint(uint64(-v), base))
-		} else {
-			p.buf.writeString(strconv.FormatUint(uint64(v), base))
-		}
-		return
-	}
-	i--
-	buf[i] = digitsByte[absV]
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
-	}
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			i--
-			buf[i] = digitsByte[16]
-			i--
-			buf[i] = '0'
-		}
-	}
-	if negative {
-		i--
-		buf[i] = '-'
-	} else if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
-		i--
-		buf[i] = ' '
-	}
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		p.buf.write(buf[i:])
-	}
-}
-
-func (p *printer) fmtInt32() {
-	v := p.arg.(int32)
-	base := p.fmt.uintbase
-
-	// Fast path for small uint64 in base 10
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallInt32Map[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
-	}
-
-	negative := v < 0
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
-	}
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
-		return
-	}
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if negative || p.fmt.plus || p.fmt.space {
-			prec--
-		}
-	}
-	i := len(buf)
-	absV := v
-	if negative {
-		absV = -v
-	}
-	switch base {
-	case 10:
-		for absV >= 10 {
-			i--
-			next := absV / 10
-			buf[i] = byte('0' + absV - next*10)
-			absV = next
-		}
-	case 16:
-		for absV >= 16 {
-			i--
-			buf[i] = digitsByte[absV&0xF]
-			absV /= 16
-		}
-	case 8:
-		for absV >= 8 {
-			i--
-			buf[i] = byte('0' + absV&7)
-			absV /= 8
-		}
-	case 2:
-		for absV >= 2 {
-			i--
-			buf[i] = byte('0' + absV&1)
-			absV /= 2
-		}
-	default:
-		if negative {
-			p.buf.writeString("-" + strconv.FormatUint(uint64(-v), base))
-		} else {
-			p.buf.writeString(strconv.FormatUint(uint64(v), base))
-		}
-		return
-	}
-	i--
-	buf[i] = digitsByte[absV]
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
-	}
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			i--
-			buf[i] = digitsByte[16]
-			i--
-			buf[i] = '0'
-		}
-	}
-	if negative {
-		i--
-		buf[i] = '-'
-	} else if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
-		i--
-		buf[i] = ' '
-	}
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		p.buf.write(buf[i:])
-	}
-}
-
-func (p *printer) fmtInt64() {
-	v := p.arg.(int64)
-	base := p.fmt.uintbase
-
-	// Fast path for small uint64 in base 10
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallInt64Map[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
-	}
-	negative := v < 0
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
-	}
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
-		return
-	}
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if negative || p.fmt.plus || p.fmt.space {
-			prec--
-		}
-	}
-	i := len(buf)
-	absV := v
-	if negative {
-		absV = -v
-	}
-	switch base {
-	case 10:
-		for absV >= 10 {
-			i--
-			next := absV / 10
-			buf[i] = byte('0' + absV - next*10)
-			absV = next
-		}
-	case 16:
-		for absV >= 16 {
-			i--
-			buf[i] = digitsByte[absV&0xF]
-			absV /= 16
-		}
-	case 8:
-		for absV >= 8 {
-			i--
-			buf[i] = byte('0' + absV&7)
-			absV /= 8
-		}
-	case 2:
-		for absV >= 2 {
-			i--
-			buf[i] = byte('0' + absV&1)
-			absV /= 2
-		}
-	default:
-		if negative {
-			p.buf.writeString("-" + strconv.FormatUint(uint64(-v), base))
-		} else {
-			p.buf.writeString(strconv.FormatUint(uint64(v), base))
-		}
-		return
-	}
-	i--
-	buf[i] = digitsByte[absV]
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
-	}
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			i--
-			buf[i] = digitsByte[16]
-			i--
-			buf[i] = '0'
-		}
-	}
-	if negative {
-		i--
-		buf[i] = '-'
-	} else if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
-		i--
-		buf[i] = ' '
-	}
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		p.buf.write(buf[i:])
-	}
-}
-
-// Unsigned integer formatting functions
-
-func (p *printer) fmtUint() {
-	v := p.arg.(uint)
-	base := p.fmt.uintbase
-	// Fast path for small uint64 in base 10
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallUintMap[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
-	}
-
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
-	}
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
-		return
-	}
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if p.fmt.plus || p.fmt.space {
-			prec--
-		}
-	}
-	i := len(buf)
-	u := v
-	switch base {
-	case 10:
-		for u >= 10 {
-			i--
-			next := u / 10
-			buf[i] = byte('0' + u - next*10)
-			u = next
-		}
-	case 16:
-		for u >= 16 {
-			i--
-			buf[i] = digitsByte[u&0xF]
-			u /= 16
-		}
-	case 8:
-		for u >= 8 {
-			i--
-			buf[i] = byte('0' + u&7)
-			u /= 8
-		}
-	case 2:
-		for u >= 2 {
-			i--
-			buf[i] = byte('0' + u&1)
-			u /= 2
-		}
-	default:
-		p.buf.writeString(strconv.FormatUint(uint64(v), base))
-		return
-	}
-	i--
-	buf[i] = digitsByte[u]
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
-	}
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			i--
-			buf[i] = digitsByte[16]
-			i--
-			buf[i] = '0'
-		}
-	}
-	if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
-		i--
-		buf[i] = ' '
-	}
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		p.buf.write(buf[i:])
-	}
-}
-
-func (p *printer) fmtUint8() {
-	v := p.arg.(uint8)
-	base := p.fmt.uintbase
-
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallUint8Map[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
-	}
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
-	}
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
-		return
-	}
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if p.fmt.plus || p.fmt.space {
-			prec--
-		}
-	}
-	i := len(buf)
-	u := v
-	switch base {
-	case 10:
-		for u >= 10 {
-			i--
-			next := u / 10
-			buf[i] = byte('0' + u - next*10)
-			u = next
-		}
-	case 16:
-		for u >= 16 {
-			i--
-			buf[i] = digitsByte[u&0xF]
-			u /= 16
-		}
-	case 8:
-		for u >= 8 {
-			i--
-			buf[i] = byte('0' + u&7)
-			u /= 8
-		}
-	case 2:
-		for u >= 2 {
-			i--
-			buf[i] = byte('0' + u&1)
-			u /= 2
-		}
-	default:
-		p.buf.writeString(strconv.FormatUint(uint64(v), base))
-		return
-	}
-	i--
-	buf[i] = digitsByte[u]
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
-	}
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			i--
-			buf[i] = digitsByte[16]
-			i--
-			buf[i] = '0'
-		}
-	}
-	if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
-		i--
-		buf[i] = ' '
-	}
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		p.buf.write(buf[i:])
-	}
-}
-
-func (p *printer) fmtUint16() {
-	v := p.arg.(uint16)
-	base := p.fmt.uintbase
-
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallUint16Map[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
-	}
-
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
-	}
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
-		return
-	}
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if p.fmt.plus || p.fmt.space {
-			prec--
-		}
-	}
-	i := len(buf)
-	u := v
-	switch base {
-	case 10:
-		for u >= 10 {
-			i--
-			next := u / 10
-			buf[i] = byte('0' + u - next*10)
-			u = next
-		}
-	case 16:
-		for u >= 16 {
-			i--
-			buf[i] = digitsByte[u&0xF]
-			u /= 16
-		}
-	case 8:
-		for u >= 8 {
-			i--
-			buf[i] = byte('0' + u&7)
-			u /= 8
-		}
-	case 2:
-		for u >= 2 {
-			i--
-			buf[i] = byte('0' + u&1)
-			u /= 2
-		}
-	default:
-		p.buf.writeString(strconv.FormatUint(uint64(v), base))
-		return
-	}
-	i--
-	buf[i] = digitsByte[u]
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
-	}
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			i--
-			buf[i] = digitsByte[16]
-			i--
-			buf[i] = '0'
-		}
-	}
-	if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
-		i--
-		buf[i] = ' '
-	}
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		p.buf.write(buf[i:])
-	}
-}
-
-func (p *printer) fmtUint32() {
-	v := p.arg.(uint32)
-	base := p.fmt.uintbase
-
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallUint32Map[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
-	}
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
-	}
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
-		return
-	}
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if p.fmt.plus || p.fmt.space {
-			prec--
-		}
-	}
-	i := len(buf)
-	u := v
-	switch base {
-	case 10:
-		for u >= 10 {
-			i--
-			next := u / 10
-			buf[i] = byte('0' + u - next*10)
-			u = next
-		}
-	case 16:
-		for u >= 16 {
-			i--
-			buf[i] = digitsByte[u&0xF]
-			u /= 16
-		}
-	case 8:
-		for u >= 8 {
-			i--
-			buf[i] = byte('0' + u&7)
-			u /= 8
-		}
-	case 2:
-		for u >= 2 {
-			i--
-			buf[i] = byte('0' + u&1)
-			u /= 2
-		}
-	default:
-		p.buf.writeString(strconv.FormatUint(uint64(v), base))
-		return
-	}
-	i--
-	buf[i] = digitsByte[u]
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
-	}
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			i--
-			buf[i] = digitsByte[16]
-			i--
-			buf[i] = '0'
-		}
-	}
-	if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
-		i--
-		buf[i] = ' '
-	}
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		p.buf.write(buf[i:])
-	}
-}
-
-func (p *printer) fmtUintptr() {
-	v := p.arg.(uintptr)
-	base := p.fmt.uintbase
-
-	if base == 10 && p.fmt.wid <= 0 && !p.fmt.precPresent && !p.fmt.plus && !p.fmt.space && !p.fmt.sharp {
-		// Check if we have this in our small int map
-		if str, ok := smallUintptrMap[v]; ok {
-			p.buf.writeString(str)
-			return
-		}
-	}
-	var digitsByte []byte
-	if p.fmt.toupper {
-		digitsByte = digitsUpper
-	} else {
-		digitsByte = digits
-	}
-	if v == 0 && p.fmt.precPresent && p.fmt.prec == 0 {
-		oldZero := p.fmt.zero
-		p.fmt.zero = false
-		if p.fmt.widPresent && p.fmt.wid > 0 {
-			for i := 0; i < p.fmt.wid; i++ {
-				p.buf.writeByte(' ')
-			}
-		}
-		p.fmt.zero = oldZero
-		return
-	}
-	const maxBufSize = 68
-	var buf [maxBufSize]byte
-	prec := 0
-	if p.fmt.precPresent {
-		prec = p.fmt.prec
-	} else if p.fmt.zero && !p.fmt.minus && p.fmt.widPresent {
-		prec = p.fmt.wid
-		if p.fmt.plus || p.fmt.space {
-			prec--
-		}
-	}
-	i := len(buf)
-	u := v
-	switch base {
-	case 10:
-		for u >= 10 {
-			i--
-			next := u / 10
-			buf[i] = byte('0' + u - next*10)
-			u = next
-		}
-	case 16:
-		for u >= 16 {
-			i--
-			buf[i] = digitsByte[u&0xF]
-			u /= 16
-		}
-	case 8:
-		for u >= 8 {
-			i--
-			buf[i] = byte('0' + u&7)
-			u /= 8
-		}
-	case 2:
-		for u >= 2 {
-			i--
-			buf[i] = byte('0' + u&1)
-			u /= 2
-		}
-	default:
-		p.buf.writeString(strconv.FormatUint(uint64(v), base))
-		return
-	}
-	i--
-	buf[i] = digitsByte[u]
-	for i > 0 && prec > len(buf)-i {
-		i--
-		buf[i] = '0'
-	}
-	if p.fmt.sharp {
-		switch base {
-		case 2:
-			i--
-			buf[i] = 'b'
-			i--
-			buf[i] = '0'
-		case 8:
-			if buf[i] != '0' {
-				i--
-				buf[i] = '0'
-			}
-		case 16:
-			i--
-			buf[i] = digitsByte[16]
-			i--
-			buf[i] = '0'
-		}
-	}
-	if p.fmt.plus {
-		i--
-		buf[i] = '+'
-	} else if p.fmt.space {
-		i--
-		buf[i] = ' '
-	}
-	if p.fmt.widPresent && p.fmt.wid > len(buf)-i {
-		width := p.fmt.wid - (len(buf) - i)
-		if !p.fmt.minus {
-			padByte := byte(' ')
-			if p.fmt.zero && !p.fmt.precPresent {
-				padByte = byte('0')
-			}
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(padByte)
-			}
-		}
-		p.buf.write(buf[i:])
-		if p.fmt.minus {
-			for w := 0; w < width; w++ {
-				p.buf.writeByte(' ')
-			}
-		}
-	} else {
-		p.buf.write(buf[i:])
+		p.buf.writeByte('-')
 	}
+	p.buf = appendDecimal(p.buf, u)
+	p.buf.writeByte(')')
 }
@@ -0,0 +1,48 @@
+package xprint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+type intLevel int8
+
+// TestIntegersMatchFmt runs every integer type through the flags, widths,
+// precisions and verbs fmt supports for integers.
+func TestIntegersMatchFmt(t *testing.T) {
+	values := []any{
+		0, 7, -7, 255, 123456789,
+		int8(-128), int8(127), int16(-300), int32(1 << 30), int64(-1 << 63),
+		uint(0), uint8(255), uint16(65535), uint32(1 << 31), uint64(1<<64 - 1), uintptr(0xdead),
+		'x', rune(0x1F600),
+	}
+	formats := []string{
+		"%d", "%v", "%+d", "% d", "%5d", "%-5d|", "%05d", "%-05d|", "%+05d", "% 05d",
+		"%.3d", "%8.3d", "%.0d", "%5.0d", "%010.4d", "%.70d", "%070d", "%#.70b", "%80d",
+		"%x", "%X", "%#x", "%#X", "%08x", "%#08x", "%-#10x|", "%+.3x", "% x",
+		"%o", "%#o", "%#08o", "%O", "%#O", "%b", "%#b",
+		"%#v", "%+v", "%+#v", "%#5v",
+		"%c", "%05c", "%5c|", "%-4c|", "%q", "%+q", "%U", "%#U", "%.6U", "%#.2U", "%010U",
+		"%s", "%e",
+	}
+	for _, format := range formats {
+		for _, v := range values {
+			if expected, got := fmt.Sprintf(format, v), xprint.Printf(format, v); got != expected {
+				t.Errorf("%s of %T(%v): expected %q, got %q", format, v, v, expected, got)
+			}
+		}
+	}
+
+	nested := struct {
+		A int16
+		B []uint32
+		C [2]intLevel
+	}{-5, []uint32{1, 1 << 31}, [2]intLevel{-1, 1}}
+	for _, format := range []string{"%v", "%+v", "%d", "%x", "%s"} {
+		if expected, got := fmt.Sprintf(format, nested), xprint.Printf(format, nested); got != expected {
+			t.Errorf("%s of %T: expected %q, got %q", format, nested, expected, got)
+		}
+	}
+}
@@ -12,6 +12,28 @@ func (p *printer) printValue(v reflect.Value, verb rune, prec int) {
 		return
 	}
 
+	plan := plainPlan
+	if p.fmt.sharpV || !isPlain(v) {
+		plan = planOf(v.Type())
+	}
+	if plan.hooks {
+		// Registered type formatters win over everything else, then Appender
+		// methods and the allocation-free paths for common standard library types
+		if p.printRegisteredValue(v, verb) || p.printAppenderValue(v, plan.appender, verb) || p.printWellKnownValue(v, verb) {
+			return
+		}
+
+		// Pointers to types implementing fmt.Formatter, such as *big.Int
+		if plan.formatter && !v.IsNil() && v.CanInterface() && p.handleFormatter(v.Interface(), verb) {
+			return
+		}
+	}
+
+	// Dump output lays out composites itself and tracks cycles per path
+	if p.fmt.pretty && p.printPretty(v, verb, prec) {
+		return
+	}
+
 	// Check for recursive pointer/interface values
 	if !p.recursing && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
 		ptr := v.Pointer()
@@ -28,12 +50,12 @@ func (p *printer) printValue(v reflect.Value, verb rune, prec int) {
 	if verb == 'v' && p.fmt.sharpV {
 		// Print type for nil pointer/interface/slice
 		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface || v.Kind() == reflect.Slice) && v.IsNil() {
-			p.buf.writeString(v.Type().String())
+			p.buf.writeString(plan.name)
 			p.buf.writeString(nilParenString)
 			return
 		}
 		// Print type for other values
-		p.buf.writeString(v.Type().String())
+		p.buf.writeString(plan.name)
 		if v.Kind() == reflect.Struct {
 			p.buf.writeByte('{')
 		} else {
@@ -50,12 +72,11 @@ func (p *printer) printValue(v reflect.Value, verb rune, prec int) {
 	switch v.Kind() {
 	case reflect.Bool:
 		p.fmt.fmtBool(v.Bool())
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		p.printInt(v.Int(), verb)
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		p.printInt(v.Uint(), verb)
-	case reflect.Float32, reflect.Float64:
-		p.printFloat(v, verb)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		p.printIntValue(v, verb)
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		p.printFloatValue(v, verb)
 	case reflect.String:
 		p.fmt.fmtString(v.String())
 	case reflect.Slice:
@@ -63,32 +84,13 @@ func (p *printer) printValue(v reflect.Value, verb rune, prec int) {
 			p.buf.writeString(nilAngleString)
 			return
 		}
-		if v.Type().Elem().Kind() == reflect.Uint8 {
+		if plan.bytes {
 			p.fmt.fmtBytes(v.Bytes())
 		} else {
-			p.buf.writeByte('[')
-			for i := range v.Len() {
-				if i > 0 {
-					p.buf.writeByte(' ')
-				}
-				p.printValue(v.Index(i), verb, prec)
-				if i == 0 { // Much simpler! For i=0,1: decrement, for i=2: don't
-					p.argNum-- // Hold for each element except the last one
-				}
-			}
-			p.argNum++
-			p.buf.writeByte(']')
+			p.printElems(v, plan, verb, prec)
 		}
 	case reflect.Array:
-		p.argNum-- // Hold argNum for the entire array
-		p.buf.writeByte('[')
-		for i := range v.Len() {
-			if i > 0 {
-				p.buf.writeByte(' ')
-			}
-			p.printValue(v.Index(i), verb, prec)
-		}
-		p.buf.writeByte(']')
+		p.printElems(v, plan, verb, prec)
 	case reflect.Map:
 		if v.IsNil() {
 			p.buf.writeString(nilAngleString)
@@ -103,28 +105,10 @@ func (p *printer) printValue(v reflect.Value, verb rune, prec int) {
 			p.printValue(key, verb, prec)
 			p.buf.writeByte(':')
 			p.printValue(v.MapIndex(key), verb, prec)
-			if i < len(keys)-1 {
-				p.argNum-- // Hold for each key-value pair except the last one
-			}
 		}
 		p.buf.writeByte(']')
 	case reflect.Struct:
-
-		p.buf.writeByte('{')
-		for i := 0; i < v.NumField(); i++ { //nolint:all //
-			if i > 0 {
-				p.buf.writeByte(' ')
-			}
-			if p.fmt.plusV {
-				p.buf.writeString(v.Type().Field(i).Name)
-				p.buf.writeByte(':')
-			}
-			p.printValue(v.Field(i), verb, prec)
-			if i < v.NumField()-1 {
-				p.argNum-- // Hold for each field except the last one
-			}
-		}
-		p.buf.writeByte('}')
+		p.printStruct(v, plan, verb, prec)
 	case reflect.Ptr:
 		if v.IsNil() {
 			p.buf.writeString(nilAngleString)
@@ -57,30 +57,9 @@ func (p *printer) printBool(arg any) {
 }
 
 func (p *printer) printReflectType(arg any) {
-	p.buf.writeString(reflect.TypeOf(arg).String())
-}
-
-func (p *printer) printComplex(v any, verb rune) {
-	p.buf.writeByte('(')
-	switch v := v.(type) {
-	case complex64:
-		p.printFloat32(real(v), verb)
-		if imag(v) >= 0 {
-			p.buf.writeByte('+')
-		}
-		p.printFloat32(imag(v), verb)
-	case complex128:
-		p.printFloat64(real(v), verb)
-		if imag(v) >= 0 {
-			p.buf.writeByte('+')
-		}
-		p.printFloat64(imag(v), verb)
-	default:
-		p.buf.writeString(percentBangString)
-		p.buf.writeByte(byte(verb))
-		p.buf.writeString(badVerbString)
+	if arg == nil {
+		p.buf.writeString(nilAngleString)
 		return
 	}
-	p.buf.writeByte('i')
-	p.buf.writeByte(')')
+	p.buf.writeString(reflect.TypeOf(arg).String())
 }
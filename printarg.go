@@ -13,10 +13,10 @@ func (p *printer) printArg() {
 	if p.arg == nil {
 		switch p.verb {
 		case 'T', 'v':
-
-			p.buf.writeString(nilString)
+			start := len(p.buf)
+			p.buf.writeString(nilAngleString)
+			p.padFrom(start)
 		default:
-
 			p.buf.writeNilArg(p.verb)
 		}
 		return
@@ -70,59 +70,23 @@ func (p *printer) printArg() {
 			boolstr := percentBangString + "s(" + "bool" + "=" + strconv.FormatBool(v) + ")"
 			p.buf = append(p.buf, boolstr...)
 		}
-	case int:
-		p.fmtInt()
-
-	case int8:
-		p.fmtInt8()
-
-	case int16:
-		p.fmtInt16()
-
-	case int32:
-		p.fmtInt32()
-
-	case int64:
-		p.fmtInt64()
-
-	case uint:
-		p.fmtUint()
-
-	case uint8:
-		p.fmtUint8()
-
-	case uint16:
-		p.fmtUint16()
-
-	case uint32:
-		p.fmtUint32()
-
-	case uint64:
-		p.fmtUint64()
-
-	case uintptr:
-		p.fmtUintptr()
-
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		p.printIntegerArg(v, p.verb)
 	case float32:
-		// If precision is explicitly specified, use printFloat
-		// Otherwise use our specialized formatter with proper defaults
-		if p.fmt.precPresent {
-			p.printFloat(v, p.verb)
-		} else {
-			p.printFloat32(v, p.verb)
-		}
+		p.printFloat32(v, p.verb)
 	case float64:
-		// If precision is explicitly specified, use printFloat
-		// Otherwise use our specialized formatter with proper defaults
-		if p.fmt.precPresent {
-			p.printFloat(v, p.verb)
-		} else {
-			p.printFloat64(v, p.verb)
-		}
-	case complex64, complex128:
-		p.printComplex(v, p.verb)
+		p.printFloat64(v, p.verb)
+	case complex64:
+		p.printComplex(complex128(v), p.verb, "complex64", reflect.Complex64)
+	case complex128:
+		p.printComplex(v, p.verb, "complex128", reflect.Complex128)
+	case Styled:
+		p.printStyled(v)
 	default:
-		if p.handleMethods(p.verb) {
+		if p.printRegistered(p.arg, p.verb) || p.printAppender(p.arg, p.verb) || p.printWellKnown(p.arg, p.verb) || p.handleFormatter(p.arg, p.verb) {
+			return
+		}
+		if p.handleMethods(p.verb) || p.printTextMarshaler(p.arg) {
 			return
 		}
 
@@ -1,8 +1,9 @@
 package xprint
 
 import (
-	"strconv"
 	"sync"
+	"sync/atomic"
+	"unsafe"
 
 	reflect "github.com/goccy/go-reflect"
 )
@@ -11,23 +12,34 @@ import (
 type printer struct {
 	// Big fields first
 	buf         buffer
+	spare       buffer  // the printer's own buffer while buf is from bufPool
+	holder      *[]byte // reused to return buf to bufPool
 	value       reflect.Value
 	arg         any
 	visitedPtrs visited
 	wrappedErrs []int
 	fmt         fmt
+	locale      *Locale // set by Printer; nil keeps fmt's output
+	colorMode   ColorMode
+	escape      EscapeMode
 
 	// Frequently updated small fields
 	argNum int
 	verb   rune
+	depth  int // nesting level for dump output
+
+	// gen counts the times the printer was freed, so a Lease can tell
+	// whether the buffer is still its own
+	gen atomic.Uint64
 
 	// Grouped booleans
-	recursing  bool
-	reordered  bool //nolint:unused
-	goodArgNum bool //nolint:unused
-	panicking  bool //nolint:unused
-	erroring   bool //nolint:unused
-	wrapErrs   bool //nolint:unused
+	recursing    bool
+	stringResult bool // the output becomes a string, see presize
+	reordered    bool //nolint:unused
+	goodArgNum   bool //nolint:unused
+	panicking    bool //nolint:unused
+	erroring     bool //nolint:unused
+	wrapErrs     bool //nolint:unused
 }
 
 // func (p *printer) argAsString() string {
@@ -55,18 +67,41 @@ func newPrinter() *printer {
 
 // free saves used pp structs in ppFree; avoids an allocation per invocation.
 func (p *printer) free() {
-	if cap(p.buf) > 1024*64 {
-		p.buf = nil
+	if cap(p.buf) > printerBufferSize {
+		p.putBuffer(p.buf)
+		p.buf = p.spare
 	} else {
 		p.buf = p.buf[:0]
 	}
+	p.spare = nil
 	p.arg = nil
 	p.value = reflect.Value{}
 	p.visitedPtrs.ptrs = nil
+	p.locale = nil
+	p.colorMode = ColorAuto
+	p.escape = EscapeNone
 	p.recursing = false
+	p.stringResult = false
+	p.gen.Add(1)
 	ppFree.Put(p)
 }
 
+// takeString frees p and returns its output. A buffer too large for the
+// printer to keep is handed to the string instead of being copied, provided
+// little of it is unused.
+func (p *printer) takeString() string {
+	var s string
+	if n := len(p.buf); cap(p.buf) > printerBufferSize && n >= cap(p.buf)/2 {
+		s = unsafe.String(unsafe.SliceData(p.buf), n)
+		// Nothing may write to the buffer from here on
+		p.buf = p.spare
+	} else {
+		s = string(p.buf)
+	}
+	p.free()
+	return s
+}
+
 var ppFree = &sync.Pool{
 	New: func() any { return new(printer) },
 }
@@ -110,20 +145,6 @@ func (p *printer) printBadVerb(verb rune) {
 	p.buf.writeString(badVerbString)
 }
 
-func (p *printer) printFloat(v any, verb rune) {
-	var str string
-	switch v := v.(type) {
-	case float32:
-		str = strconv.FormatFloat(float64(v), byte(verb), p.fmt.prec, 32)
-	case float64:
-		str = strconv.FormatFloat(v, byte(verb), p.fmt.prec, 64)
-	default:
-		p.printBadVerb(verb)
-		return
-	}
-	p.buf.writeString(str)
-}
-
 // handleMethods checks if the argument implements special formatting interfaces.
 func (p *printer) catchPanic(arg any, verb rune, method string) {
 	if err := recover(); err != nil {
@@ -0,0 +1,73 @@
+package xprint
+
+import (
+	"errors"
+	"io"
+)
+
+// Printer formats like the package-level functions, with its own options.
+// The zero value is ready to use and produces the same output as Printf.
+// A Printer must not be modified while it is in use by other goroutines.
+type Printer struct {
+	// Locale controls the digit grouping used by the ' flag and the decimal
+	// separator of floating-point output. The zero value keeps fmt's output
+	// and groups with "," in threes for the ' flag.
+	Locale Locale
+	// Color controls whether values wrapped with Color emit ANSI sequences.
+	// The zero value follows the NO_COLOR environment variable.
+	Color ColorMode
+	// Escape escapes every operand for HTML, JSON strings, shell commands or
+	// SQL literals. Literal text is left untouched.
+	Escape EscapeMode
+}
+
+// NewPrinter returns a Printer using the given locale.
+func NewPrinter(locale Locale) *Printer {
+	return &Printer{Locale: locale}
+}
+
+// Sprintf formats according to a format specifier and returns the resulting string.
+func (pr *Printer) Sprintf(format string, args ...any) string {
+	p := pr.newPrinter()
+	p.stringResult = true
+	p.printf(format, args)
+	return p.takeString()
+}
+
+// Printf is an alias of Sprintf, mirroring the package-level Printf.
+func (pr *Printer) Printf(format string, args ...any) string {
+	return pr.Sprintf(format, args...)
+}
+
+// Appendf formats according to a format specifier, appends the result to b
+// and returns the updated slice.
+func (pr *Printer) Appendf(b []byte, format string, args ...any) []byte {
+	p := pr.newPrinter()
+	p.printf(format, args)
+	b = append(b, p.buf...)
+	p.free()
+	return b
+}
+
+// Fprintf formats according to a format specifier and writes to w.
+func (pr *Printer) Fprintf(w io.Writer, format string, args ...any) (int, error) {
+	p := pr.newPrinter()
+	p.printf(format, args)
+	n, err := w.Write(p.buf)
+	p.free()
+	if err != nil {
+		return n, errors.New("xprint: " + err.Error())
+	}
+	return n, nil
+}
+
+// newPrinter returns a pooled printer carrying the options of pr.
+func (pr *Printer) newPrinter() *printer {
+	p := newPrinter()
+	if pr.Locale != (Locale{}) {
+		p.locale = &pr.Locale
+	}
+	p.colorMode = pr.Color
+	p.escape = pr.Escape
+	return p
+}
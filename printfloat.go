@@ -1,39 +1,252 @@
 package xprint
 
-import "strconv"
+import (
+	"strconv"
 
-// printFloat64 handles float64 formatting with default precision
+	reflect "github.com/goccy/go-reflect"
+)
+
+// printFloat64 formats a float64 for verb, printing fmt's %!verb(type=value)
+// for verbs floats do not support.
 func (p *printer) printFloat64(v float64, verb rune) {
+	if !p.fmtFloat(v, 64, verb) {
+		p.badFloatVerb(verb, "float64", complex(v, 0), reflect.Float64)
+	}
+}
+
+// printFloat32 formats a float32 for verb, printing fmt's %!verb(type=value)
+// for verbs floats do not support.
+func (p *printer) printFloat32(v float32, verb rune) {
+	if !p.fmtFloat(float64(v), 32, verb) {
+		p.badFloatVerb(verb, "float32", complex(float64(v), 0), reflect.Float32)
+	}
+}
+
+// printComplex formats a complex number for verb, printing fmt's
+// %!verb(type=value) for verbs complex numbers do not support.
+func (p *printer) printComplex(v complex128, verb rune, typ string, kind reflect.Kind) {
+	size := 128
+	if kind == reflect.Complex64 {
+		size = 64
+	}
+	if !p.fmtComplex(v, size, verb) {
+		p.badFloatVerb(verb, typ, v, kind)
+	}
+}
+
+// printFloatValue formats a float or complex number reached by reflection.
+func (p *printer) printFloatValue(v reflect.Value, verb rune) {
+	var c complex128
+	var ok bool
+	switch k := v.Kind(); k {
+	case reflect.Float32, reflect.Float64:
+		c = complex(v.Float(), 0)
+		ok = p.fmtFloat(real(c), v.Type().Bits(), verb)
+	default:
+		c = v.Complex()
+		ok = p.fmtComplex(c, v.Type().Bits(), verb)
+	}
+	if !ok {
+		p.badFloatVerb(verb, v.Type().String(), c, v.Kind())
+	}
+}
+
+// fmtFloat formats v, a float of the given bit size, for verb with fmt's
+// default precisions. It reports false, writing nothing, for verbs floats do
+// not support. Grouped or localized numbers take a separate pass.
+func (p *printer) fmtFloat(v float64, size int, verb rune) bool {
+	if p.localizes() {
+		return p.printLocalized(func() bool { return p.fmtFloat(v, size, verb) })
+	}
 	switch verb {
+	case 'v':
+		p.appendFloat(v, size, 'g', -1)
+	case 'b', 'g', 'G', 'x', 'X':
+		p.appendFloat(v, size, verb, -1)
 	case 'f', 'e', 'E':
-		// Default precision 6
-		p.buf.writeString(strconv.FormatFloat(v, byte(verb), 6, 64))
+		p.appendFloat(v, size, verb, 6)
 	case 'F':
-		// Special case: F uses 'f' format with precision 6
-		p.buf.writeString(strconv.FormatFloat(v, 'f', 6, 64))
-	case 'v':
-		// Special case: %v uses 'g' format
-		p.buf.writeString(strconv.FormatFloat(v, 'g', -1, 64))
+		p.appendFloat(v, size, 'f', 6)
 	default:
-		// 'g', 'G', 'b', 'x', 'X' - default precision -1
-		p.buf.writeString(strconv.FormatFloat(v, byte(verb), -1, 64))
+		return false
 	}
+	return true
 }
 
-// printFloat32 handles float32 formatting with default precision
-func (p *printer) printFloat32(v float32, verb rune) {
+// fmtComplex formats v as (r+ji), applying the flags, width and precision to
+// both parts. The imaginary part always has a sign.
+func (p *printer) fmtComplex(v complex128, size int, verb rune) bool {
 	switch verb {
-	case 'f', 'e', 'E':
-		// Default precision 6
-		p.buf.writeString(strconv.FormatFloat(float64(v), byte(verb), 6, 32))
-	case 'F':
-		// Special case: F uses 'f' format with precision 6
-		p.buf.writeString(strconv.FormatFloat(float64(v), 'f', 6, 32))
-	case 'v':
-		// Special case: %v uses 'g' format
-		p.buf.writeString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	case 'v', 'b', 'g', 'G', 'x', 'X', 'f', 'F', 'e', 'E':
+	default:
+		return false
+	}
+	plus := p.fmt.plus
+	p.buf.writeByte('(')
+	p.fmtFloat(real(v), size/2, verb)
+	p.fmt.plus = true
+	p.fmtFloat(imag(v), size/2, verb)
+	p.buf.writeString("i)")
+	p.fmt.plus = plus
+	return true
+}
+
+// appendFloat formats v like strconv.AppendFloat, then applies fmt's sign, #,
+// zero padding and width rules. The number is appended straight into the
+// buffer behind a reserved sign byte and fixed up in place, so no string is
+// allocated on the way.
+func (p *printer) appendFloat(v float64, size int, verb rune, prec int) {
+	f := &p.fmt
+	if f.precPresent {
+		prec = f.prec
+	}
+	// Without flags or width strconv's output is already final
+	if !f.plus && !f.space && !f.sharp && !f.widPresent {
+		p.buf = strconv.AppendFloat(p.buf, v, byte(verb), prec, size)
+		return
+	}
+	start := len(p.buf)
+	p.buf = strconv.AppendFloat(append(p.buf, '+'), v, byte(verb), prec, size)
+
+	// num is where the signed number begins: the reserved byte unless
+	// strconv wrote a sign of its own
+	num := start
+	if c := p.buf[start+1]; c == '-' || c == '+' {
+		num++
+	}
+	if f.space && p.buf[num] == '+' && !f.plus {
+		p.buf[num] = ' '
+	}
+
+	// Infinities and NaN don't look like numbers and are never zero padded
+	if c := p.buf[num+1]; c == 'I' || c == 'N' {
+		if c == 'N' && !f.space && !f.plus {
+			num++
+		}
+		p.placeNumber(start, num, true, false)
+		return
+	}
+
+	if f.sharp && verb != 'b' {
+		p.restoreFloatZeros(num, verb, prec)
+	}
+
+	// The sign is shown if asked for or negative
+	p.placeNumber(start, num, f.plus || p.buf[num] != '+', f.zero)
+}
+
+// restoreFloatZeros makes the number at p.buf[num:] keep its decimal point and,
+// for %g and %x, its trailing zeros, as the # flag asks.
+func (p *printer) restoreFloatZeros(num int, verb rune, prec int) {
+	digits := 0
+	switch verb {
+	case 'v', 'g', 'G', 'x':
+		digits = prec
+		// Without an explicit precision %g shows six digits
+		if digits == -1 {
+			digits = 6
+		}
+	}
+
+	// Everything from tail on is the exponent, which stays last
+	tail := len(p.buf)
+	hasDecimalPoint := false
+	sawNonzeroDigit := false
+	for i := num + 1; i < tail; i++ {
+		switch c := p.buf[i]; {
+		case c == '.':
+			hasDecimalPoint = true
+		case c == 'p' || c == 'P' || (c == 'e' || c == 'E') && verb != 'x' && verb != 'X':
+			tail = i
+		default:
+			if c != '0' {
+				sawNonzeroDigit = true
+			}
+			// Count the significant digits after the first non-zero one
+			if sawNonzeroDigit {
+				digits--
+			}
+		}
+	}
+
+	insert := max(digits, 0)
+	if !hasDecimalPoint {
+		// A lone 0 counts as a digit once
+		if tail == num+2 && p.buf[num+1] == '0' && insert > 0 {
+			insert--
+		}
+		insert++
+	}
+	if insert == 0 {
+		return
+	}
+	end := len(p.buf)
+	p.writePadding(insert, '0')
+	copy(p.buf[tail+insert:], p.buf[tail:end])
+	for i := tail; i < tail+insert; i++ {
+		p.buf[i] = '0'
+	}
+	if !hasDecimalPoint {
+		p.buf[tail] = '.'
+	}
+}
+
+// placeNumber moves the number at p.buf[num:], whose first byte is a sign, to
+// start and pads it to the directive's width. The sign is dropped unless
+// signed is set. Zero padding goes between the sign and the digits.
+func (p *printer) placeNumber(start, num int, signed, zero bool) {
+	f := &p.fmt
+	if !signed {
+		num++
+	}
+	n := len(p.buf) - num
+	pad := 0
+	if f.widPresent {
+		pad = f.wid - n
+	}
+	if pad <= 0 || f.minus {
+		copy(p.buf[start:], p.buf[num:])
+		p.buf = p.buf[:start+n]
+		p.writePadding(pad, ' ')
+		return
+	}
+
+	fill := byte(' ')
+	if zero {
+		fill = '0'
+		if signed {
+			// Keep the sign in front of the zeros
+			p.buf[start] = p.buf[num]
+			start++
+			num++
+		}
+	}
+	// Slide the number right to make room for the padding
+	end := len(p.buf)
+	p.writePadding(start+pad-num, fill)
+	copy(p.buf[start+pad:], p.buf[num:end])
+	for i := start; i < start+pad; i++ {
+		p.buf[i] = fill
+	}
+}
+
+// badFloatVerb writes fmt's %!verb(type=value) for a float or complex
+// operand of kind; floats are passed as the real part of v.
+func (p *printer) badFloatVerb(verb rune, typ string, v complex128, kind reflect.Kind) {
+	p.buf.writeString(percentBangString)
+	p.buf.writeRune(verb)
+	p.buf.writeByte('(')
+	p.buf.writeString(typ)
+	p.buf.writeByte('=')
+	switch kind {
+	case reflect.Float32:
+		p.fmtFloat(real(v), 32, 'v')
+	case reflect.Float64:
+		p.fmtFloat(real(v), 64, 'v')
+	case reflect.Complex64:
+		p.fmtComplex(v, 64, 'v')
 	default:
-		// 'g', 'G', 'b', 'x', 'X' - default precision -1
-		p.buf.writeString(strconv.FormatFloat(float64(v), byte(verb), -1, 32))
+		p.fmtComplex(v, 128, 'v')
 	}
+	p.buf.writeByte(')')
 }
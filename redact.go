@@ -0,0 +1,97 @@
+package xprint
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	reflect "github.com/goccy/go-reflect"
+)
+
+// fieldAction describes how printValue renders a single struct field.
+type fieldAction uint8
+
+const (
+	fieldShow   fieldAction = iota // print the field normally
+	fieldRedact                    // print redactedString instead of the value
+	fieldOmit                      // skip the field entirely
+)
+
+// structFields holds the cached per-field actions for a struct type.
+type structFields struct {
+	gen     uint64 // cacheGeneration the actions were computed under
+	actions []fieldAction
+	// plain is true when every field is shown, so callers can skip lookups.
+	plain bool
+}
+
+var (
+	// structFieldsCache maps reflect.Type to *structFields.
+	structFieldsCache sync.Map
+	redactFunc        atomic.Pointer[func(name string) bool]
+)
+
+// SetRedactFunc installs a predicate deciding, by field name, which struct
+// fields are printed as [REDACTED] in addition to those tagged
+// `xprint:"redact"`. Passing nil removes the predicate.
+func SetRedactFunc(fn func(name string) bool) {
+	if fn == nil {
+		redactFunc.Store(nil)
+	} else {
+		redactFunc.Store(&fn)
+	}
+	// Cached actions and plans depend on the predicate.
+	cacheGeneration.Add(1)
+}
+
+// fieldsOf returns the field actions for the struct type t, computing them
+// from the `xprint` struct tags and the redaction predicate on first use and
+// after the predicate changes.
+func fieldsOf(t reflect.Type) *structFields {
+	gen := cacheGeneration.Load()
+	if cached, ok := structFieldsCache.Load(t); ok {
+		if sf := cached.(*structFields); sf.gen == gen { //nolint:forcetypeassert // cache only holds *structFields
+			return sf
+		}
+	}
+
+	var redact func(string) bool
+	if fn := redactFunc.Load(); fn != nil {
+		redact = *fn
+	}
+
+	n := t.NumField()
+	sf := &structFields{gen: gen, actions: make([]fieldAction, n), plain: true}
+	for i := range n {
+		field := t.Field(i)
+		action := fieldShow
+		for opt := range strings.SplitSeq(field.Tag.Get("xprint"), ",") {
+			switch opt {
+			case "-":
+				action = fieldOmit
+			case "redact":
+				if action != fieldOmit {
+					action = fieldRedact
+				}
+			}
+		}
+		if action == fieldShow && redact != nil && redact(field.Name) {
+			action = fieldRedact
+		}
+		if action != fieldShow {
+			sf.plain = false
+		}
+		sf.actions[i] = action
+	}
+
+	structFieldsCache.Store(t, sf)
+	return sf
+}
+
+// action returns how field i is rendered.
+func (sf *structFields) action(i int) fieldAction {
+	if sf.plain {
+		return fieldShow
+	}
+	return sf.actions[i]
+}
@@ -0,0 +1,86 @@
+package xprint_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+type loginRequest struct {
+	User     string
+	Password string `xprint:"redact"`
+	Session  string `xprint:"-"`
+	Token    string
+	Attempts int
+}
+
+func TestRedactTags(t *testing.T) {
+	req := loginRequest{User: "alice", Password: "hunter2", Session: "s3cr3t", Token: "abc", Attempts: 3}
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "{alice [REDACTED] abc 3}"},
+		{"%+v", "{User:alice Password:[REDACTED] Token:abc Attempts:3}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if o := xprint.Printf(tc.format, req); o != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, o)
+			}
+		})
+	}
+
+	if o := xprint.Printf("%#v", req); strings.Contains(o, "hunter2") || strings.Contains(o, "s3cr3t") {
+		t.Errorf("Expected secrets to be hidden under %%#v, got %s", o)
+	}
+	if o := xprint.Sdump(req); strings.Contains(o, "hunter2") || strings.Contains(o, "Session") {
+		t.Errorf("Expected secrets to be hidden in dump, got %s", o)
+	}
+}
+
+func TestRedactFunc(t *testing.T) {
+	xprint.SetRedactFunc(func(name string) bool { return name == "Token" })
+	defer xprint.SetRedactFunc(nil)
+
+	req := []loginRequest{{User: "bob", Token: "abc"}}
+	expected := "[{User:bob Password:[REDACTED] Token:[REDACTED] Attempts:0}]"
+	if o := xprint.Printf("%+v", req); o != expected {
+		t.Errorf("Expected %s, got %s", expected, o)
+	}
+}
+
+// TestRedactFuncConcurrent changes the predicate while other goroutines
+// print, so plans are built concurrently with the change. None built under
+// an earlier predicate may be used once it is set.
+func TestRedactFuncConcurrent(t *testing.T) {
+	defer xprint.SetRedactFunc(nil)
+	req := loginRequest{User: "bob", Token: "abc"}
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				_ = xprint.Printf("%+v", req)
+			}
+		}()
+	}
+	for i := range 100 {
+		if i%2 == 0 {
+			xprint.SetRedactFunc(nil)
+		} else {
+			xprint.SetRedactFunc(func(name string) bool { return name == "Token" })
+		}
+	}
+	wg.Wait()
+
+	expected := "{User:bob Password:[REDACTED] Token:[REDACTED] Attempts:0}"
+	if o := xprint.Printf("%+v", req); o != expected {
+		t.Errorf("Expected %s, got %s", expected, o)
+	}
+}
@@ -0,0 +1,103 @@
+package xprint
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+
+	reflect "github.com/goccy/go-reflect"
+)
+
+// typeFormatter appends the formatted form of v, whose dynamic type is the
+// registered type, to dst.
+type typeFormatter func(dst []byte, v any, verb rune) []byte
+
+var (
+	typeMu sync.Mutex
+	// typeFormatters maps reflect.Type to typeFormatter. Like customVerbs it is
+	// copied on write so lookups never lock.
+	typeFormatters atomic.Pointer[map[reflect.Type]typeFormatter]
+)
+
+// RegisterType installs fn as the formatter for values of type T, for types
+// whose String method cannot be added or changed. The formatter takes
+// precedence over Error and String methods and reflection, both for top-level
+// arguments and for values nested in slices, maps, structs and pointers.
+// T must be a concrete type; lookups use the dynamic type of each value.
+// Registering a type again replaces its formatter. Values stored in unexported
+// struct fields cannot be passed to fn and keep their default formatting.
+func RegisterType[T any](fn func(dst []byte, v T, verb rune) []byte) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if fn == nil {
+		unregisterType(t)
+		return
+	}
+	storeType(t, func(dst []byte, v any, verb rune) []byte {
+		return fn(dst, v.(T), verb) //nolint:forcetypeassert // only called for values of type T
+	})
+}
+
+// UnregisterType removes the formatter registered for T, if any.
+func UnregisterType[T any]() {
+	unregisterType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+func storeType(t reflect.Type, fn typeFormatter) {
+	typeMu.Lock()
+	defer typeMu.Unlock()
+	table := make(map[reflect.Type]typeFormatter)
+	if old := typeFormatters.Load(); old != nil {
+		maps.Copy(table, *old)
+	}
+	table[t] = fn
+	typeFormatters.Store(&table)
+	cacheGeneration.Add(1)
+}
+
+func unregisterType(t reflect.Type) {
+	typeMu.Lock()
+	defer typeMu.Unlock()
+	old := typeFormatters.Load()
+	if old == nil {
+		return
+	}
+	if _, ok := (*old)[t]; !ok {
+		return
+	}
+	table := maps.Clone(*old)
+	delete(table, t)
+	if len(table) == 0 {
+		typeFormatters.Store(nil)
+	} else {
+		typeFormatters.Store(&table)
+	}
+	cacheGeneration.Add(1)
+}
+
+// printRegistered formats arg with its registered type formatter, if any.
+func (p *printer) printRegistered(arg any, verb rune) bool {
+	table := typeFormatters.Load()
+	if table == nil || arg == nil {
+		return false
+	}
+	fn, ok := (*table)[reflect.TypeOf(arg)]
+	if !ok {
+		return false
+	}
+	p.buf = fn(p.buf, arg, verb)
+	return true
+}
+
+// printRegisteredValue is printRegistered for values reached by reflection.
+func (p *printer) printRegisteredValue(v reflect.Value, verb rune) bool {
+	table := typeFormatters.Load()
+	if table == nil || !v.CanInterface() {
+		return false
+	}
+	fn, ok := (*table)[v.Type()]
+	if !ok {
+		return false
+	}
+	p.buf = fn(p.buf, v.Interface(), verb)
+	return true
+}
@@ -0,0 +1,71 @@
+package xprint_test
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+type testUUID [4]byte
+
+type testID int
+
+type session struct {
+	ID      testUUID
+	Timeout time.Duration
+}
+
+func TestRegisterType(t *testing.T) {
+	xprint.RegisterType(func(dst []byte, v testUUID, _ rune) []byte {
+		return hex.AppendEncode(dst, v[:])
+	})
+	defer xprint.UnregisterType[testUUID]()
+	xprint.RegisterType(func(dst []byte, v time.Duration, _ rune) []byte {
+		return append(strconv.AppendInt(dst, v.Milliseconds(), 10), "ms"...)
+	})
+	defer xprint.UnregisterType[time.Duration]()
+	xprint.RegisterType(func(dst []byte, v testID, _ rune) []byte {
+		return strconv.AppendInt(append(dst, '#'), int64(v), 10)
+	})
+	defer xprint.UnregisterType[testID]()
+
+	id := testUUID{0xde, 0xad, 0xbe, 0xef}
+	testCases := []struct {
+		name     string
+		format   string
+		arg      any
+		expected string
+	}{
+		{"top level", "%v", id, "deadbeef"},
+		{"overrides String", "%v", 1500 * time.Millisecond, "1500ms"},
+		{"slice", "%v", []testUUID{id, id}, "[deadbeef deadbeef]"},
+		{"map", "%v", map[string]time.Duration{"t": time.Second}, "map[t:1000ms]"},
+		{"struct", "%+v", session{ID: id, Timeout: time.Second}, "{ID:deadbeef Timeout:1000ms}"},
+		{"pointer", "%v", &session{ID: id}, "&{deadbeef 0ms}"},
+		{"method-less in interface slice", "%v", []any{testID(7), 8}, "[#7 8]"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if o := xprint.Printf(tc.format, tc.arg); o != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, o)
+			}
+		})
+	}
+
+	if o := string(xprint.Append(nil, id)); o != "deadbeef" {
+		t.Errorf("Expected deadbeef, got %s", o)
+	}
+}
+
+func TestUnregisterType(t *testing.T) {
+	xprint.RegisterType(func(dst []byte, _ testUUID, _ rune) []byte {
+		return append(dst, "uuid"...)
+	})
+	xprint.UnregisterType[testUUID]()
+	if o := xprint.Printf("%v", testUUID{1, 2, 3, 4}); o != "[1 2 3 4]" {
+		t.Errorf("Expected [1 2 3 4], got %s", o)
+	}
+}
@@ -0,0 +1,294 @@
+package xprint
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Align is the alignment of a table column.
+type Align uint8
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// Column describes one column of a Table.
+type Column struct {
+	// Header is written in the first row when any column has one.
+	Header string
+	// Align places cells within the column width.
+	Align Align
+	// Verb is the Printf directive used for the column's cells, for example
+	// "%.2f" or "%x". Empty means "%v".
+	Verb string
+}
+
+// Table writes rows of values as aligned columns, replacing text/tabwriter
+// plus fmt. Column widths are measured in terminal cells, so combining marks
+// take no space and East Asian wide characters take two.
+//
+// Cells are formatted when added; Flush computes the widths and streams the
+// rows to the writer. A Table is not safe for concurrent use.
+type Table struct {
+	// Sep separates columns. The zero value uses two spaces.
+	Sep string
+
+	w       io.Writer
+	columns []Column
+	data    []byte // formatted cells, back to back
+	cells   []tableCell
+	widths  []int
+	nrows   int
+}
+
+// tableCell locates a formatted cell in Table.data.
+type tableCell struct {
+	end   int // end offset in data; the start is the previous cell's end
+	width int // display width
+	row   int
+	col   int
+}
+
+// NewTable returns a Table writing to w with the given columns. Rows may have
+// more values than columns; the extra columns use the defaults.
+func NewTable(w io.Writer, columns ...Column) *Table {
+	t := &Table{w: w, columns: columns}
+	t.addHeaders()
+	return t
+}
+
+// addHeaders adds the header row if any column has a header.
+func (t *Table) addHeaders() {
+	if !slices.ContainsFunc(t.columns, func(c Column) bool { return c.Header != "" }) {
+		return
+	}
+	for i, c := range t.columns {
+		t.data = append(t.data, c.Header...)
+		t.addCell(i)
+	}
+	t.nrows++
+}
+
+// Row formats values into a new row.
+func (t *Table) Row(values ...any) {
+	p := newPrinter()
+	for i := range values {
+		verb := "%v"
+		if i < len(t.columns) && t.columns[i].Verb != "" {
+			verb = t.columns[i].Verb
+		}
+		p.buf = p.buf[:0]
+		p.printf(verb, values[i:i+1])
+		t.data = append(t.data, p.buf...)
+		t.addCell(i)
+	}
+	p.free()
+	t.nrows++
+}
+
+func (t *Table) addCell(col int) {
+	start := 0
+	if n := len(t.cells); n > 0 {
+		start = t.cells[n-1].end
+	}
+	width := bytesWidth(t.data[start:])
+	t.cells = append(t.cells, tableCell{end: len(t.data), width: width, row: t.nrows, col: col})
+	for len(t.widths) <= col {
+		t.widths = append(t.widths, 0)
+	}
+	t.widths[col] = max(t.widths[col], width)
+}
+
+// Flush writes all rows added since the last Flush and resets the table,
+// keeping its columns and headers.
+func (t *Table) Flush() error {
+	sep := t.Sep
+	if sep == "" {
+		sep = "  "
+	}
+	p := newPrinter()
+	defer p.free()
+
+	start := 0
+	for i := 0; i < len(t.cells); {
+		row := t.cells[i].row
+		p.buf = p.buf[:0]
+		pending := 0 // padding owed before the next cell, dropped at line end
+		for ; i < len(t.cells) && t.cells[i].row == row; i++ {
+			c := t.cells[i]
+			col := c.col
+			align := AlignLeft
+			if col < len(t.columns) {
+				align = t.columns[col].Align
+			}
+			pad := t.widths[col] - c.width
+			left := 0
+			switch align {
+			case AlignRight:
+				left = pad
+			case AlignCenter:
+				left = pad / 2
+			}
+			if col > 0 {
+				p.buf.writeString(sep)
+			}
+			p.writePadding(pending+left, ' ')
+			p.buf.write(t.data[start:c.end])
+			pending = pad - left
+			start = c.end
+		}
+		p.buf.writeByte('\n')
+		if _, err := t.w.Write(p.buf); err != nil {
+			t.reset()
+			return errors.New("xprint: " + err.Error())
+		}
+	}
+	t.reset()
+	return nil
+}
+
+func (t *Table) reset() {
+	t.data = t.data[:0]
+	t.cells = t.cells[:0]
+	clear(t.widths)
+	t.widths = t.widths[:0]
+	t.nrows = 0
+	t.addHeaders()
+}
+
+// StringWidth returns the number of terminal cells s occupies: zero for
+// combining marks and format characters, two for East Asian wide and
+// fullwidth characters and one otherwise.
+func StringWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += RuneWidth(r)
+	}
+	return n
+}
+
+// bytesWidth is StringWidth for formatted output. ANSI SGR sequences written
+// by Color take no space.
+func bytesWidth(b []byte) int {
+	n := 0
+	for i := 0; i < len(b); {
+		if b[i] == '\x1b' && i+1 < len(b) && b[i+1] == '[' {
+			j := i + 2
+			for j < len(b) && (b[j] == ';' || isDigit(b[j])) {
+				j++
+			}
+			if j < len(b) && b[j] == 'm' {
+				i = j + 1
+				continue
+			}
+		}
+		r, size := utf8.DecodeRune(b[i:])
+		n += RuneWidth(r)
+		i += size
+	}
+	return n
+}
+
+// RuneWidth returns the number of terminal cells r occupies.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x300:
+		if r < 0x20 || (r >= 0x7f && r < 0xa0) {
+			return 0
+		}
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// wideRanges lists the East Asian Wide and Fullwidth blocks, and the emoji
+// blocks terminals render two cells wide.
+var wideRanges = [...][2]rune{
+	{0x1100, 0x115f},   // Hangul Jamo initials
+	{0x231a, 0x231b},   // watch, hourglass
+	{0x2329, 0x232a},   // angle brackets
+	{0x23e9, 0x23ec},   // media controls
+	{0x23f0, 0x23f0},   // alarm clock
+	{0x23f3, 0x23f3},   // hourglass
+	{0x25fd, 0x25fe},   // small squares
+	{0x2614, 0x2615},   // umbrella, hot beverage
+	{0x2648, 0x2653},   // zodiac
+	{0x267f, 0x267f},   // wheelchair
+	{0x2693, 0x2693},   // anchor
+	{0x26a1, 0x26a1},   // high voltage
+	{0x26aa, 0x26ab},   // circles
+	{0x26bd, 0x26be},   // balls
+	{0x26c4, 0x26c5},   // snowman, sun
+	{0x26ce, 0x26ce},   // ophiuchus
+	{0x26d4, 0x26d4},   // no entry
+	{0x26ea, 0x26ea},   // church
+	{0x26f2, 0x26f3},   // fountain, golf
+	{0x26f5, 0x26f5},   // sailboat
+	{0x26fa, 0x26fa},   // tent
+	{0x26fd, 0x26fd},   // fuel pump
+	{0x2705, 0x2705},   // check mark
+	{0x270a, 0x270b},   // fists
+	{0x2728, 0x2728},   // sparkles
+	{0x274c, 0x274c},   // cross mark
+	{0x274e, 0x274e},   // cross mark
+	{0x2753, 0x2755},   // question marks
+	{0x2757, 0x2757},   // exclamation mark
+	{0x2795, 0x2797},   // math signs
+	{0x27b0, 0x27b0},   // curly loop
+	{0x27bf, 0x27bf},   // double curly loop
+	{0x2b1b, 0x2b1c},   // large squares
+	{0x2b50, 0x2b50},   // star
+	{0x2b55, 0x2b55},   // circle
+	{0x2e80, 0x303e},   // CJK radicals, punctuation
+	{0x3041, 0x33ff},   // Hiragana, Katakana, CJK compatibility
+	{0x3400, 0x4dbf},   // CJK extension A
+	{0x4e00, 0x9fff},   // CJK unified ideographs
+	{0xa000, 0xa4cf},   // Yi
+	{0xa960, 0xa97f},   // Hangul Jamo extended A
+	{0xac00, 0xd7a3},   // Hangul syllables
+	{0xf900, 0xfaff},   // CJK compatibility ideographs
+	{0xfe10, 0xfe19},   // vertical forms
+	{0xfe30, 0xfe6f},   // CJK compatibility forms
+	{0xff00, 0xff60},   // fullwidth forms
+	{0xffe0, 0xffe6},   // fullwidth signs
+	{0x16fe0, 0x16fe4}, // ideographic symbols
+	{0x17000, 0x18cff}, // Tangut
+	{0x1b000, 0x1b2ff}, // Kana supplement
+	{0x1f004, 0x1f004}, // mahjong
+	{0x1f0cf, 0x1f0cf}, // joker
+	{0x1f18e, 0x1f18e}, // AB button
+	{0x1f191, 0x1f19a}, // squared words
+	{0x1f200, 0x1f2ff}, // enclosed ideographic supplement
+	{0x1f300, 0x1f64f}, // pictographs, emoticons
+	{0x1f680, 0x1f6ff}, // transport
+	{0x1f7e0, 0x1f7eb}, // colored shapes
+	{0x1f90c, 0x1f9ff}, // supplemental symbols
+	{0x1fa70, 0x1faff}, // symbols extended A
+	{0x20000, 0x2fffd}, // CJK extension B and later
+	{0x30000, 0x3fffd}, // CJK extension G and later
+}
+
+func isWide(r rune) bool {
+	lo, hi := 0, len(wideRanges)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		switch {
+		case r < wideRanges[m][0]:
+			hi = m
+		case r > wideRanges[m][1]:
+			lo = m + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package xprint_test
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func TestTable(t *testing.T) {
+	var sb strings.Builder
+	tbl := xprint.NewTable(&sb,
+		xprint.Column{Header: "NAME"},
+		xprint.Column{Header: "SIZE", Align: xprint.AlignRight, Verb: "%d"},
+		xprint.Column{Header: "RATIO", Align: xprint.AlignCenter, Verb: "%.2f"},
+	)
+	tbl.Row("alpha", 1, 0.5)
+	tbl.Row("日本語", 12345, 12.125)
+	tbl.Row("e\u0301", 7)
+	if err := tbl.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "" +
+		"NAME     SIZE  RATIO\n" +
+		"alpha       1  0.50\n" +
+		"日本語  12345  12.12\n" +
+		"e\u0301           7\n"
+	if o := sb.String(); o != expected {
+		t.Errorf("Expected\n%q\ngot\n%q", expected, o)
+	}
+
+	// Flush resets the rows but keeps the headers
+	sb.Reset()
+	tbl.Row("x", 1, 1.0)
+	tbl.Flush() //nolint:errcheck //
+	if o := sb.String(); o != "NAME  SIZE  RATIO\nx        1  1.00\n" {
+		t.Errorf("Unexpected output after Flush:\n%s", o)
+	}
+}
+
+func TestStringWidth(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected int
+	}{
+		{"abc", 3},
+		{"日本語", 6},
+		{"e\u0301", 1},
+		{"한국", 4},
+		{"ｆｕｌｌ", 8},
+		{"🚀x", 3},
+	}
+	for _, tc := range testCases {
+		if w := xprint.StringWidth(tc.s); w != tc.expected {
+			t.Errorf("StringWidth(%q): expected %d, got %d", tc.s, tc.expected, w)
+		}
+	}
+}
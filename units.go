@@ -0,0 +1,293 @@
+package xprint
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Units formatting. Every helper takes a precision: a negative precision
+// means up to one decimal with trailing zeros trimmed ("1 KiB", "1.5 KiB"),
+// otherwise exactly prec decimals are written.
+
+var (
+	iecUnits = [...]string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	siBytes  = [...]string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	siLarge  = [...]string{"", "k", "M", "G", "T", "P", "E"}
+	siSmall  = [...]string{"", "m", "µ", "n", "p"}
+)
+
+// durationUnits lists the compact duration units from largest to smallest.
+var durationUnits = [...]struct {
+	d    time.Duration
+	name string
+}{
+	{24 * time.Hour, "d"},
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+	{time.Millisecond, "ms"},
+	{time.Microsecond, "µs"},
+	{time.Nanosecond, "ns"},
+}
+
+// AppendBytes appends n as a byte size with IEC prefixes, such as "12.3 MiB".
+func AppendBytes(dst []byte, n int64, prec int) []byte {
+	return appendByteSize(dst, n, prec, 1024, &iecUnits)
+}
+
+// AppendBytesSI appends n as a byte size with SI prefixes, such as "12.9 MB".
+func AppendBytesSI(dst []byte, n int64, prec int) []byte {
+	return appendByteSize(dst, n, prec, 1000, &siBytes)
+}
+
+// AppendSI appends v scaled to an SI prefix, such as "1.2k" or "350m".
+func AppendSI(dst []byte, v float64, prec int) []byte {
+	if v == 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return appendScaled(dst, v, prec)
+	}
+	f := math.Abs(v)
+	unit := ""
+	switch {
+	case f >= 1:
+		i := 0
+		for i < len(siLarge)-1 && roundTo(f, prec) >= 1000 {
+			f /= 1000
+			i++
+		}
+		unit = siLarge[i]
+	default:
+		i := 0
+		for i < len(siSmall)-1 && roundTo(f, prec) < 1 {
+			f *= 1000
+			i++
+		}
+		unit = siSmall[i]
+	}
+	if v < 0 {
+		f = -f
+	}
+	dst = appendScaled(dst, f, prec)
+	return append(dst, unit...)
+}
+
+// AppendCompactDuration appends d in its largest whole unit, such as "1.5s",
+// "250ms" or "3.2h". Days are written as "d".
+func AppendCompactDuration(dst []byte, d time.Duration, prec int) []byte {
+	if d == 0 {
+		return append(dst, "0s"...)
+	}
+	u := d
+	if u < 0 {
+		dst = append(dst, '-')
+		if u == math.MinInt64 {
+			u = math.MaxInt64
+		} else {
+			u = -u
+		}
+	}
+	for _, unit := range durationUnits {
+		if u < unit.d && unit.d != time.Nanosecond {
+			continue
+		}
+		if unit.d == time.Nanosecond {
+			dst = strconv.AppendInt(dst, int64(u), 10)
+		} else {
+			dst = appendScaled(dst, float64(u)/float64(unit.d), prec)
+		}
+		return append(dst, unit.name...)
+	}
+	return dst
+}
+
+// FormatBytes returns n as a byte size with IEC prefixes.
+func FormatBytes(n int64) string { return string(AppendBytes(nil, n, -1)) }
+
+// FormatBytesSI returns n as a byte size with SI prefixes.
+func FormatBytesSI(n int64) string { return string(AppendBytesSI(nil, n, -1)) }
+
+// FormatSI returns v scaled to an SI prefix.
+func FormatSI(v float64) string { return string(AppendSI(nil, v, -1)) }
+
+// FormatCompactDuration returns d in its largest whole unit.
+func FormatCompactDuration(d time.Duration) string {
+	return string(AppendCompactDuration(nil, d, -1))
+}
+
+func appendByteSize(dst []byte, n int64, prec int, base float64, units *[7]string) []byte {
+	if n < 0 {
+		dst = append(dst, '-')
+	}
+	u := uint64(n) //nolint:gosec // sign handled above
+	if n < 0 {
+		u = -u
+	}
+	if float64(u) < base {
+		dst = strconv.AppendUint(dst, u, 10)
+		return append(append(dst, ' '), units[0]...)
+	}
+	f := float64(u)
+	i := 0
+	for i < len(units)-1 && roundTo(f, prec) >= base {
+		f /= base
+		i++
+	}
+	dst = appendScaled(dst, f, prec)
+	return append(append(dst, ' '), units[i]...)
+}
+
+// appendScaled appends f with the unit precision rules.
+func appendScaled(dst []byte, f float64, prec int) []byte {
+	if prec >= 0 {
+		return strconv.AppendFloat(dst, f, 'f', prec, 64)
+	}
+	start := len(dst)
+	dst = strconv.AppendFloat(dst, f, 'f', 1, 64)
+	if n := len(dst); n-start >= 2 && dst[n-1] == '0' && dst[n-2] == '.' {
+		dst = dst[:n-2]
+	}
+	return dst
+}
+
+// roundTo rounds f as appendScaled would print it.
+func roundTo(f float64, prec int) float64 {
+	if prec < 0 {
+		prec = 1
+	}
+	pow := math.Pow10(prec)
+	return math.Round(f*pow) / pow
+}
+
+// Ready-made verb formatters for RegisterVerb, for example
+//
+//	xprint.RegisterVerb('H', xprint.BytesVerb)
+//	xprint.Printf("%H", 12900000) // 12.3 MiB
+//
+// They accept integer arguments (and floats for SIVerb, time.Duration for
+// DurationVerb) and honour precision, width and the '-' flag.
+var (
+	BytesVerb    VerbFunc = func(s State, arg any) bool { return printUnit(s, arg, unitIEC) }
+	BytesSIVerb  VerbFunc = func(s State, arg any) bool { return printUnit(s, arg, unitSIBytes) }
+	SIVerb       VerbFunc = func(s State, arg any) bool { return printUnit(s, arg, unitSI) }
+	DurationVerb VerbFunc = func(s State, arg any) bool { return printUnit(s, arg, unitDuration) }
+)
+
+type unitKind uint8
+
+const (
+	unitIEC unitKind = iota
+	unitSIBytes
+	unitSI
+	unitDuration
+)
+
+// printUnit formats arg into a stack buffer and writes it padded. When s is
+// the printer itself the bytes go straight into its buffer.
+func printUnit(s State, arg any, kind unitKind) bool {
+	prec, ok := s.Precision()
+	if !ok {
+		prec = -1
+	}
+	var scratch [32]byte
+	b, ok := appendUnit(scratch[:0], arg, prec, kind)
+	if !ok {
+		return false
+	}
+	// Padding counts cells, so µ in durations is one column
+	width, _ := s.Width()
+	width -= bytesWidth(b)
+	if p, isPrinter := s.(*printer); isPrinter {
+		if !p.fmt.minus {
+			p.writePadding(width, ' ')
+		}
+		p.buf.write(b)
+		if p.fmt.minus {
+			p.writePadding(width, ' ')
+		}
+		return true
+	}
+	pad := width
+	for ; pad > 0 && !s.Flag('-'); pad-- {
+		s.WriteString(" ") //nolint:errcheck // State writes never fail
+	}
+	s.Write(b) //nolint:errcheck // State writes never fail
+	for ; pad > 0; pad-- {
+		s.WriteString(" ") //nolint:errcheck // State writes never fail
+	}
+	return true
+}
+
+func appendUnit(dst []byte, arg any, prec int, kind unitKind) ([]byte, bool) {
+	if kind == unitDuration {
+		d, ok := arg.(time.Duration)
+		if !ok {
+			n, isInt := unitInt(arg)
+			if !isInt {
+				return dst, false
+			}
+			d = time.Duration(n)
+		}
+		return AppendCompactDuration(dst, d, prec), true
+	}
+	n, isInt := unitInt(arg)
+	switch kind {
+	case unitIEC, unitSIBytes:
+		if !isInt {
+			return dst, false
+		}
+		if kind == unitIEC {
+			return AppendBytes(dst, n, prec), true
+		}
+		return AppendBytesSI(dst, n, prec), true
+	default:
+		if isInt {
+			return AppendSI(dst, float64(n), prec), true
+		}
+		switch v := arg.(type) {
+		case float64:
+			return AppendSI(dst, v, prec), true
+		case float32:
+			return AppendSI(dst, float64(v), prec), true
+		}
+		return dst, false
+	}
+}
+
+// unitInt converts the integer types to int64. Values of uint64 beyond the
+// int64 range saturate.
+func unitInt(arg any) (int64, bool) {
+	switch v := arg.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case time.Duration:
+		return int64(v), true
+	case uint:
+		return saturate(uint64(v)), true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return saturate(v), true
+	case uintptr:
+		return saturate(uint64(v)), true
+	}
+	return 0, false
+}
+
+func saturate(v uint64) int64 {
+	if v > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(v)
+}
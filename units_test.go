@@ -0,0 +1,80 @@
+package xprint_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func TestUnits(t *testing.T) {
+	testCases := []struct {
+		got      string
+		expected string
+	}{
+		{xprint.FormatBytes(0), "0 B"},
+		{xprint.FormatBytes(512), "512 B"},
+		{xprint.FormatBytes(1024), "1 KiB"},
+		{xprint.FormatBytes(1536), "1.5 KiB"},
+		{xprint.FormatBytes(12900000), "12.3 MiB"},
+		{xprint.FormatBytes(1048575), "1 MiB"},
+		{xprint.FormatBytes(-2048), "-2 KiB"},
+		{string(xprint.AppendBytes(nil, 1536, 2)), "1.50 KiB"},
+		{xprint.FormatBytesSI(1500), "1.5 kB"},
+		{xprint.FormatSI(1234), "1.2k"},
+		{xprint.FormatSI(0.35), "350m"},
+		{xprint.FormatSI(-2.5e9), "-2.5G"},
+		{xprint.FormatSI(12), "12"},
+		{xprint.FormatCompactDuration(1500 * time.Millisecond), "1.5s"},
+		{xprint.FormatCompactDuration(250 * time.Millisecond), "250ms"},
+		{xprint.FormatCompactDuration(-90 * time.Minute), "-1.5h"},
+		{xprint.FormatCompactDuration(36 * time.Hour), "1.5d"},
+		{xprint.FormatCompactDuration(42), "42ns"},
+	}
+
+	for _, tc := range testCases {
+		if tc.got != tc.expected {
+			t.Errorf("Expected %s, got %s", tc.expected, tc.got)
+		}
+	}
+}
+
+func TestUnitVerbs(t *testing.T) {
+	verbs := map[rune]xprint.VerbFunc{'Y': xprint.BytesVerb, 'Z': xprint.SIVerb, 'W': xprint.DurationVerb}
+	for verb, fn := range verbs {
+		if err := xprint.RegisterVerb(verb, fn); err != nil {
+			t.Fatal(err)
+		}
+		defer xprint.UnregisterVerb(verb)
+	}
+
+	testCases := []struct {
+		format   string
+		arg      any
+		expected string
+	}{
+		{"%Y", 12900000, "12.3 MiB"},
+		{"%.2Y", uint64(1536), "1.50 KiB"},
+		{"[%10Y]", 1024, "[     1 KiB]"},
+		{"[%-8Z]", 1234.0, "[1.2k    ]"},
+		{"%W", 1500 * time.Millisecond, "1.5s"},
+		{"[%8W]", 250 * time.Microsecond, "[   250µs]"},
+		{"[%-8W]", 250 * time.Microsecond, "[250µs   ]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			if o := xprint.Printf(tc.format, tc.arg); o != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, o)
+			}
+		})
+	}
+
+	if o := xprint.Printf("%Y", "x"); !strings.Contains(o, "BADVERB") {
+		t.Errorf("Expected a bad verb for strings, got %s", o)
+	}
+	if n := testing.AllocsPerRun(100, func() { _ = xprint.Appendf(make([]byte, 0, 64), "%Y", 12900000) }); n > 1 {
+		t.Errorf("Expected at most 1 allocation, got %v", n)
+	}
+}
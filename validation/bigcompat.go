@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+
+	"gopkg.hlmpn.dev/pkg/go-logger"
+	"gopkg.hlmpn.dev/pkg/xprint"
+	"gopkg.hlmpn.dev/pkg/xprint/validation/internal/largeints"
+)
+
+// bigFormats are the directives compared for math/big values.
+var bigFormats = []string{
+	"%v", "%d", "%x", "%X", "%#x", "%o", "%O", "%b", "%+d", "%60d", "%-60d|", "%060d",
+	"%e", "%.3e", "%E", "%f", "%.20f", "%g", "%.30g", "%40.2f", "%-40.2f|", "%+.5e",
+}
+
+// BigCompatTest prints a compatibility table comparing xprint.Printf with
+// fmt.Sprintf for *big.Int and *big.Float values generated by largeints.
+// *big.Rat is only checked for %v, since fmt has no number formatting for it.
+func BigCompatTest() {
+	logger.Log("Comparing math/big formatting between fmt and xprint...")
+	LogLine()
+
+	values := []struct {
+		name string
+		arg  any
+	}{
+		{"big.Int 64 bit", largeints.BigInt(64)},
+		{"big.Int 256 bit", largeints.BigInt(256)},
+		{"big.Int 4096 bit", largeints.BigInt(4096)},
+		{"big.Float prec 53", largeints.BigFloat(53, 100)},
+		{"big.Float prec 256", largeints.BigFloat(256, 1000)},
+	}
+
+	failed := 0
+	logger.Logf("%-20s %-10s %s", "VALUE", "FORMAT", "RESULT")
+	for _, v := range values {
+		for _, format := range bigFormats {
+			xf := xprint.Printf(format, v.arg)
+			ff := fmt.Sprintf(format, v.arg)
+			if xf != ff {
+				failed++
+				logger.LogErrorf("%-20s %-10s MISMATCH", v.name, format)
+				logger.LogPurplef("fmt output: %s", ff)
+				logger.LogOrangef("xprint output: %s", xf)
+				continue
+			}
+			logger.LogSuccessf("%-20s %-10s ok", v.name, format)
+		}
+	}
+
+	r := largeints.BigRat(512)
+	if xf, ff := xprint.Printf("%v", r), fmt.Sprintf("%v", r); xf != ff {
+		failed++
+		logger.LogErrorf("%-20s %-10s MISMATCH", "big.Rat 512 bit", "%v")
+	} else {
+		logger.LogSuccessf("%-20s %-10s ok", "big.Rat 512 bit", "%v")
+	}
+
+	LogLine()
+	if failed > 0 {
+		logger.LogErrorf("[BigCompat] ERROR: %d mismatches", failed)
+		return
+	}
+	logger.LogSuccessf("[BigCompat] Success: all %d cases match", len(values)*len(bigFormats)+1)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"strings"
 	"time"
@@ -106,6 +107,32 @@ func LargeStringN(n int) string {
 	return strings.ToUpper(result)
 }
 
+// BigInt returns a random arbitrary-precision integer with up to bits bits,
+// negated half of the time.
+func BigInt(bits int) *big.Int {
+	n := new(big.Int).Rand(rng, new(big.Int).Lsh(big.NewInt(1), uint(bits))) //nolint:gosec // bits is small and positive
+	if RandomBool() {
+		n.Neg(n)
+	}
+	return n
+}
+
+// BigFloat returns a random big.Float with prec bits of mantissa and an
+// exponent anywhere in ±exp.
+func BigFloat(prec uint, exp int) *big.Float {
+	f := new(big.Float).SetPrec(prec).SetInt(BigInt(int(prec)))
+	return f.SetMantExp(f, rng.Intn(2*exp+1)-exp-int(prec)) //nolint:gosec // prec is small
+}
+
+// BigRat returns a random fraction of two large integers.
+func BigRat(bits int) *big.Rat {
+	den := BigInt(bits)
+	if den.Sign() == 0 {
+		den.SetInt64(1)
+	}
+	return new(big.Rat).SetFrac(BigInt(bits), den)
+}
+
 // Inefficient retrieval methods
 type TestWrapper struct{}
 
@@ -0,0 +1,76 @@
+package largeints_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+	"gopkg.hlmpn.dev/pkg/xprint/validation/internal/largeints"
+)
+
+// leaf, branch and tree nest structs by value. fmt prints pointers below the
+// top level as addresses, so largeints.DeeplyNestedStruct would compare
+// different output.
+type leaf struct {
+	ID   int
+	Name string
+}
+
+type branch struct {
+	Leaf   leaf
+	Leaves []leaf
+	Weight float64
+}
+
+type tree struct {
+	Root     branch
+	Branches []branch
+}
+
+func newTree() tree {
+	var t tree
+	for i := range 10 {
+		br := branch{Leaf: leaf{i, "branch"}, Weight: float64(i) / 4}
+		for j := range 10 {
+			br.Leaves = append(br.Leaves, leaf{i*10 + j, "leaf"})
+		}
+		t.Branches = append(t.Branches, br)
+	}
+	t.Root = t.Branches[0]
+	return t
+}
+
+// BenchmarkStructPlans prints the same struct types over and over, the case
+// the per-type formatting plans in xprint are meant for.
+func BenchmarkStructPlans(b *testing.B) {
+	li := largeints.TestWrapper{}
+	cases := []struct {
+		name   string
+		format string
+		arg    any
+	}{
+		{"SimpleStruct", "%+v", li.SimpleStruct()},
+		{"SimpleStructs", "%v", []largeints.SimpleStruct{li.SimpleStruct(), li.SimpleStruct(), li.SimpleStruct()}},
+		{"NestedStructs", "%+v", newTree()},
+		{"IntSlice", "%v", li.IntSlice()[:10000]},
+	}
+
+	for _, c := range cases {
+		if f, x := fmt.Sprintf(c.format, c.arg), xprint.Sprintf(c.format, c.arg); f != x {
+			b.Fatalf("%s: xprint and fmt differ:\n%.200s\n%.200s", c.name, x, f)
+		}
+		buf := make([]byte, 0, 1<<20)
+		b.Run(c.name+"/fmt", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				buf = fmt.Appendf(buf[:0], c.format, c.arg)
+			}
+		})
+		b.Run(c.name+"/xprint", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				buf = xprint.Appendf(buf[:0], c.format, c.arg)
+			}
+		})
+	}
+}
@@ -39,6 +39,8 @@ func main() {
 		TypesBenchmark()
 	case "test-floats":
 		TestFloats()
+	case "big-compat":
+		BigCompatTest()
 	}
 
 }
@@ -0,0 +1,155 @@
+package xprint
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// State is the printer state handed to custom verb formatters. It gives access
+// to the flags, width and precision of the directive and lets the formatter
+// append its output directly to the printer buffer.
+type State interface {
+	// Write appends b to the output. It never fails.
+	Write(b []byte) (n int, err error)
+	// WriteString appends s to the output. It never fails.
+	WriteString(s string) (n int, err error)
+	// Width returns the value of the width option and whether it has been set.
+	Width() (wid int, ok bool)
+	// Precision returns the value of the precision option and whether it has been set.
+	Precision() (prec int, ok bool)
+	// Flag reports whether the flag c, a character, has been set.
+	Flag(c int) bool
+}
+
+// VerbFunc formats arg for a custom verb. It returns false if it does not
+// handle arg, in which case the verb is reported as a bad verb.
+type VerbFunc func(s State, arg any) bool
+
+var (
+	ErrVerbReserved   = errors.New("xprint: verb is reserved by the standard verbs or flags")
+	ErrVerbRegistered = errors.New("xprint: verb is already registered")
+	ErrVerbInvalid    = errors.New("xprint: custom verbs must be printable ASCII characters")
+)
+
+// reservedVerbs holds every byte printf interprets itself, either as a
+// standard verb or as part of the flags, width, precision and argument index.
+const reservedVerbs = "%vTtbcdoOqxXUeEfFgGspjw#0+- '.*[]123456789"
+
+// verbTable maps ASCII verbs to their formatter. Verbs are read from the format
+// string one byte at a time, so only ASCII verbs can ever be looked up.
+type verbTable [utf8.RuneSelf]VerbFunc
+
+var (
+	verbMu sync.Mutex
+	// customVerbs is replaced wholesale on every change so printf can read it
+	// without locking.
+	customVerbs atomic.Pointer[verbTable]
+)
+
+// RegisterVerb makes printf call fn for every directive using verb, for
+// example %H for human readable sizes. Registration is safe for concurrent
+// use with formatting. Standard verbs, flag characters and verbs that are
+// already registered are rejected.
+func RegisterVerb(verb rune, fn VerbFunc) error {
+	if verb <= ' ' || verb >= utf8.RuneSelf-1 || fn == nil {
+		return ErrVerbInvalid
+	}
+	if strings.ContainsRune(reservedVerbs, verb) {
+		return ErrVerbReserved
+	}
+
+	verbMu.Lock()
+	defer verbMu.Unlock()
+	var table verbTable
+	if old := customVerbs.Load(); old != nil {
+		if old[verb] != nil {
+			return ErrVerbRegistered
+		}
+		table = *old
+	}
+	table[verb] = fn
+	customVerbs.Store(&table)
+	return nil
+}
+
+// UnregisterVerb removes the formatter registered for verb, if any.
+func UnregisterVerb(verb rune) {
+	if verb < 0 || verb >= utf8.RuneSelf {
+		return
+	}
+	verbMu.Lock()
+	defer verbMu.Unlock()
+	old := customVerbs.Load()
+	if old == nil || old[verb] == nil {
+		return
+	}
+	table := *old
+	table[verb] = nil
+	customVerbs.Store(&table)
+}
+
+// lookupVerb returns the custom formatter for verb, or nil.
+func lookupVerb(verb rune) VerbFunc {
+	table := customVerbs.Load()
+	if table == nil || verb < 0 || verb >= utf8.RuneSelf {
+		return nil
+	}
+	return table[verb]
+}
+
+// printCustomVerb runs fn for the current argument, recovering from panics in
+// the same way methods are handled.
+func (p *printer) printCustomVerb(fn VerbFunc) {
+	verb := p.verb
+	defer func() {
+		if r := recover(); r != nil {
+			p.buf.writeString(percentBangString)
+			p.buf.writeRune(verb)
+			p.buf.writeString(panicString)
+			p.buf.writeString("verb formatter: ")
+			p.buf.writeString(Printf("%v", r))
+			p.buf.writeByte(')')
+		}
+	}()
+	if !fn(p, p.arg) {
+		p.printBadVerb(verb)
+	}
+}
+
+// Write implements State.
+func (p *printer) Write(b []byte) (int, error) {
+	p.buf.write(b)
+	return len(b), nil
+}
+
+// WriteString implements State.
+func (p *printer) WriteString(s string) (int, error) {
+	p.buf.writeString(s)
+	return len(s), nil
+}
+
+// Width implements State.
+func (p *printer) Width() (int, bool) { return p.fmt.wid, p.fmt.widPresent }
+
+// Precision implements State.
+func (p *printer) Precision() (int, bool) { return p.fmt.prec, p.fmt.precPresent }
+
+// Flag implements State.
+func (p *printer) Flag(c int) bool {
+	switch c {
+	case '-':
+		return p.fmt.minus
+	case '+':
+		return p.fmt.plus || p.fmt.plusV
+	case '#':
+		return p.fmt.sharp || p.fmt.sharpV
+	case ' ':
+		return p.fmt.space
+	case '0':
+		return p.fmt.zero
+	}
+	return false
+}
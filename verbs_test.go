@@ -0,0 +1,83 @@
+package xprint_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func TestRegisterVerb(t *testing.T) {
+	err := xprint.RegisterVerb('H', func(s xprint.State, arg any) bool {
+		n, ok := arg.(int)
+		if !ok {
+			return false
+		}
+		if s.Flag('+') {
+			s.WriteString("+") //nolint:errcheck //
+		}
+		s.WriteString(strconv.Itoa(n/1024) + "KiB") //nolint:errcheck //
+		return true
+	})
+	if err != nil {
+		t.Fatalf("RegisterVerb: %v", err)
+	}
+	defer xprint.UnregisterVerb('H')
+
+	testCases := []struct {
+		format   string
+		arg      any
+		expected string
+	}{
+		{"size=%H", 4096, "size=4KiB"},
+		{"size=%+H", 2048, "size=+2KiB"},
+		{"size=%H", "x", "size=%!H%!(BADVERB)"},
+	}
+	for _, tc := range testCases {
+		if o := xprint.Printf(tc.format, tc.arg); o != tc.expected {
+			t.Errorf("Expected %s, got %s", tc.expected, o)
+		}
+	}
+}
+
+func TestRegisterVerbConflicts(t *testing.T) {
+	noop := func(xprint.State, any) bool { return true }
+	if err := xprint.RegisterVerb('d', noop); !errors.Is(err, xprint.ErrVerbReserved) {
+		t.Errorf("Expected ErrVerbReserved for %%d, got %v", err)
+	}
+	if err := xprint.RegisterVerb('é', noop); !errors.Is(err, xprint.ErrVerbInvalid) {
+		t.Errorf("Expected ErrVerbInvalid for non-ASCII verb, got %v", err)
+	}
+	if err := xprint.RegisterVerb('Q', noop); err != nil {
+		t.Fatalf("RegisterVerb: %v", err)
+	}
+	defer xprint.UnregisterVerb('Q')
+	if err := xprint.RegisterVerb('Q', noop); !errors.Is(err, xprint.ErrVerbRegistered) {
+		t.Errorf("Expected ErrVerbRegistered, got %v", err)
+	}
+}
+
+func TestRegisterVerbConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := range 8 {
+		verb := rune('J' + i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			xprint.RegisterVerb(verb, func(s xprint.State, _ any) bool { //nolint:errcheck //
+				s.WriteString("ok") //nolint:errcheck //
+				return true
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			_ = xprint.Printf("%d %c", 1, verb)
+		}()
+	}
+	wg.Wait()
+	for i := range 8 {
+		xprint.UnregisterVerb(rune('J' + i))
+	}
+}
@@ -0,0 +1,225 @@
+package xprint
+
+import (
+	"encoding"
+	"net/netip"
+	"sync/atomic"
+	"time"
+
+	reflect "github.com/goccy/go-reflect"
+)
+
+// timeLayout is the layout used by time.Time.String.
+const timeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	addrType     = reflect.TypeOf(netip.Addr{})
+	addrPortType = reflect.TypeOf(netip.AddrPort{})
+	prefixType   = reflect.TypeOf(netip.Prefix{})
+
+	useTextMarshaler atomic.Bool
+)
+
+// UseTextMarshaler controls whether values without Error or String methods
+// are formatted through encoding.TextAppender or encoding.TextMarshaler before
+// falling back to reflection. It is off by default, matching fmt.
+func UseTextMarshaler(enabled bool) {
+	useTextMarshaler.Store(enabled)
+}
+
+// printWellKnown appends common standard library types for %v and %s without
+// going through their String methods, which allocate. The output matches the
+// String methods exactly.
+func (p *printer) printWellKnown(arg any, verb rune) bool {
+	if (verb != 'v' && verb != 's') || p.fmt.widPresent || p.fmt.precPresent || p.fmt.sharpV {
+		return false
+	}
+	switch v := arg.(type) {
+	case time.Time:
+		// Times carrying a monotonic reading print an m=±value suffix only
+		// String knows how to produce.
+		if v != v.Round(0) {
+			return false
+		}
+		p.buf = v.AppendFormat(p.buf, timeLayout)
+	case time.Duration:
+		p.buf = appendDuration(p.buf, v)
+	case netip.Addr:
+		if !v.IsValid() {
+			p.buf.writeString("invalid IP")
+			return true
+		}
+		p.buf = v.AppendTo(p.buf)
+	case netip.AddrPort:
+		if !v.IsValid() {
+			p.buf.writeString("invalid AddrPort")
+			return true
+		}
+		p.buf = v.AppendTo(p.buf)
+	case netip.Prefix:
+		if !v.IsValid() {
+			p.buf.writeString("invalid Prefix")
+			return true
+		}
+		p.buf = v.AppendTo(p.buf)
+	default:
+		return false
+	}
+	return true
+}
+
+// printWellKnownValue is printWellKnown for values reached by reflection.
+// Only the type is compared, so unrelated values pay no interface conversion.
+func (p *printer) printWellKnownValue(v reflect.Value, verb rune) bool {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		if t != timeType && t != addrType && t != addrPortType && t != prefixType {
+			return false
+		}
+	case reflect.Int64:
+		if v.Type() != durationType {
+			return false
+		}
+	default:
+		return false
+	}
+	if !v.CanInterface() {
+		return false
+	}
+	return p.printWellKnown(v.Interface(), verb)
+}
+
+// printTextMarshaler formats arg through encoding.TextAppender or
+// encoding.TextMarshaler when enabled with UseTextMarshaler.
+func (p *printer) printTextMarshaler(arg any) bool {
+	if !useTextMarshaler.Load() {
+		return false
+	}
+	switch v := arg.(type) {
+	case encoding.TextAppender:
+		b, err := v.AppendText(p.buf)
+		if err != nil {
+			return false
+		}
+		p.buf = b
+	case encoding.TextMarshaler:
+		b, err := v.MarshalText()
+		if err != nil {
+			return false
+		}
+		p.buf.write(b)
+	default:
+		return false
+	}
+	return true
+}
+
+// appendDuration appends d in the format of time.Duration.String.
+func appendDuration(dst []byte, d time.Duration) []byte {
+	// Largest time is 2540400h10m10.000000000s
+	var buf [32]byte
+	w := len(buf)
+
+	u := uint64(d) //nolint:gosec // sign handled below
+	neg := d < 0
+	if neg {
+		u = -u
+	}
+
+	if u < uint64(time.Second) {
+		// Special case: if duration is smaller than a second,
+		// use smaller units, like 1.2ms
+		var prec int
+		w--
+		buf[w] = 's'
+		w--
+		switch {
+		case u == 0:
+			buf[w] = '0'
+			return append(dst, buf[w:]...)
+		case u < uint64(time.Microsecond):
+			prec = 0
+			buf[w] = 'n'
+		case u < uint64(time.Millisecond):
+			prec = 3
+			// U+00B5 'µ' micro sign == 0xC2 0xB5
+			w--
+			copy(buf[w:], "µ")
+		default:
+			prec = 6
+			buf[w] = 'm'
+		}
+		w, u = durationFrac(buf[:w], u, prec)
+		w = durationInt(buf[:w], u)
+	} else {
+		w--
+		buf[w] = 's'
+
+		w, u = durationFrac(buf[:w], u, 9)
+
+		// u is now integer seconds
+		w = durationInt(buf[:w], u%60)
+		u /= 60
+
+		// u is now integer minutes
+		if u > 0 {
+			w--
+			buf[w] = 'm'
+			w = durationInt(buf[:w], u%60)
+			u /= 60
+
+			// u is now integer hours
+			if u > 0 {
+				w--
+				buf[w] = 'h'
+				w = durationInt(buf[:w], u)
+			}
+		}
+	}
+
+	if neg {
+		w--
+		buf[w] = '-'
+	}
+	return append(dst, buf[w:]...)
+}
+
+// durationFrac formats the fraction of v/10**prec into the tail of buf,
+// omitting trailing zeros and the decimal point when the fraction is zero.
+func durationFrac(buf []byte, v uint64, prec int) (int, uint64) {
+	w := len(buf)
+	printed := false
+	for range prec {
+		digit := v % 10
+		printed = printed || digit != 0
+		if printed {
+			w--
+			buf[w] = byte(digit) + '0'
+		}
+		v /= 10
+	}
+	if printed {
+		w--
+		buf[w] = '.'
+	}
+	return w, v
+}
+
+// durationInt formats v into the tail of buf and returns the start index.
+func durationInt(buf []byte, v uint64) int {
+	w := len(buf)
+	if v == 0 {
+		w--
+		buf[w] = '0'
+		return w
+	}
+	for v > 0 {
+		w--
+		buf[w] = byte(v%10) + '0'
+		v /= 10
+	}
+	return w
+}
@@ -0,0 +1,89 @@
+package xprint_test
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"gopkg.hlmpn.dev/pkg/xprint"
+)
+
+func TestWellKnownTypes(t *testing.T) {
+	loc := time.FixedZone("CEST", 2*60*60)
+	testCases := []struct {
+		name string
+		arg  any
+	}{
+		{"time", time.Date(2025, 3, 7, 12, 30, 45, 123456789, loc)},
+		{"time utc", time.Date(2025, 3, 7, 12, 30, 45, 0, time.UTC)},
+		{"time monotonic", time.Now()},
+		{"duration", 90*time.Minute + 1500*time.Millisecond},
+		{"duration small", 1500 * time.Nanosecond},
+		{"duration zero", time.Duration(0)},
+		{"duration negative", -3 * time.Millisecond},
+		{"addr v4", netip.MustParseAddr("192.168.0.1")},
+		{"addr v6", netip.MustParseAddr("2001:db8::1")},
+		{"addr invalid", netip.Addr{}},
+		{"addrport", netip.MustParseAddrPort("[::1]:8080")},
+		{"addrport invalid", netip.AddrPort{}},
+		{"prefix", netip.MustParsePrefix("10.0.0.0/8")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, format := range []string{"%v", "%s", "[%v]"} {
+				o := xprint.Printf(format, tc.arg)
+				fo := fmt.Sprintf(format, tc.arg)
+				if o != fo {
+					t.Errorf("%s: Expected %s, got %s", format, fo, o)
+				}
+			}
+		})
+	}
+}
+
+func TestWellKnownNested(t *testing.T) {
+	type event struct {
+		At   time.Time
+		Took time.Duration
+	}
+	e := event{At: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), Took: 2 * time.Second}
+	expected := "{At:2025-01-02 03:04:05 +0000 UTC Took:2s}"
+	if o := xprint.Printf("%+v", e); o != expected {
+		t.Errorf("Expected %s, got %s", expected, o)
+	}
+}
+
+type textOnly struct{ name string }
+
+func (t textOnly) MarshalText() ([]byte, error) {
+	return []byte("text:" + t.name), nil
+}
+
+func TestUseTextMarshaler(t *testing.T) {
+	v := textOnly{"a"}
+	if o := xprint.Printf("%v", v); o != "{a}" {
+		t.Errorf("Expected {a} while disabled, got %s", o)
+	}
+
+	xprint.UseTextMarshaler(true)
+	defer xprint.UseTextMarshaler(false)
+	if o := xprint.Printf("%v", v); o != "text:a" {
+		t.Errorf("Expected text:a, got %s", o)
+	}
+}
+
+func TestWellKnownAllocs(t *testing.T) {
+	tm := time.Date(2025, 3, 7, 12, 30, 45, 0, time.UTC)
+	d := 1500 * time.Millisecond
+	addr := netip.MustParseAddr("10.1.2.3")
+	buf := make([]byte, 0, 256)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = xprint.Appendf(buf[:0], "%v %v %v", tm, d, addr)
+	})
+	// Only boxing the time.Time and netip.Addr operands may allocate.
+	if allocs > 2 {
+		t.Errorf("Expected at most 2 allocations, got %v", allocs)
+	}
+}
@@ -3,6 +3,7 @@ package xprint_test
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 
 	"gopkg.hlmpn.dev/pkg/xprint"
@@ -131,6 +132,24 @@ func TestArgMismatchHypotesis(t *testing.T) {
 	}
 }
 
+// TestCompositeArgsKeepPosition checks that printing a slice, array, map or
+// struct leaves the arguments of later verbs in place.
+func TestCompositeArgsKeepPosition(t *testing.T) {
+	for _, arg := range []any{
+		[]int{1, 2, 3},
+		[3]string{"a", "b", "c"},
+		map[string]int{"a": 1},
+		struct{ A, B, C int }{1, 2, 3},
+	} {
+		format := "%v %d %s"
+		o := xprint.Printf(format, arg, 5, "x")
+		fo := fmt.Sprintf(format, arg, 5, "x")
+		if o != fo {
+			t.Errorf("Expected %s, got %s", fo, o)
+		}
+	}
+}
+
 // Test nil formatting
 func TestNilFormatting(t *testing.T) {
 	TestAppendNil(t)
@@ -139,6 +158,13 @@ func TestNilFormatting(t *testing.T) {
 	if o != fo {
 		t.Errorf("Expected %s, got %s", fo, o)
 	}
+	for _, format := range []string{"%v %+v", "%#v %T", "%7v|%-7v|", "%d %x"} {
+		o := xprint.Printf(format, nil, nil)
+		fo := fmt.Sprintf(format, nil, nil)
+		if o != fo {
+			t.Errorf("%s: Expected %s, got %s", format, fo, o)
+		}
+	}
 }
 
 func TestPrintfComplex(t *testing.T) {
@@ -160,3 +186,48 @@ func TestPrintfComplex(t *testing.T) {
 		})
 	}
 }
+
+// TestFormatScan covers literal runs, %% and plain %s directives, which are
+// copied without parsing, on both sides of the printer hand-over.
+func TestFormatScan(t *testing.T) {
+	long := strings.Repeat("literal text ", 20)
+	testCases := []struct {
+		format string
+		args   []any
+	}{
+		{"%s and %s", []any{"a", []byte("b")}},
+		{"100%% of %s%%", []any{"x"}},
+		{"[%5s|%-3s]", []any{"a", "b"}},
+		{"%s then %d then %s", []any{"a", 1, "b"}},
+		{"%s %s", []any{"a", 2}},
+		{long + "%s" + long + "%d%%", []any{"a", 3}},
+		{"%s %s", []any{"only one"}},
+		{"%s trailing %", []any{"x"}},
+	}
+	for _, tc := range testCases {
+		if expected, got := fmt.Sprintf(tc.format, tc.args...), xprint.Printf(tc.format, tc.args...); got != expected {
+			t.Errorf("%q: expected %q, got %q", tc.format, expected, got)
+		}
+	}
+}
+
+// TestLargeOutputAllocs checks that the buffer is sized once for outputs made
+// of several large operands instead of growing while they are written.
+func TestLargeOutputAllocs(t *testing.T) {
+	a, b := strings.Repeat("a", 40<<10), []byte(strings.Repeat("b", 30<<10))
+	format, args := "%s|%d|%s|%d", []any{a, -12345, b, uint64(1) << 63}
+	expected := fmt.Sprintf(format, args...)
+	if got := xprint.Printf(format, args...); got != expected {
+		t.Fatalf("output differs from fmt in length %d vs %d", len(got), len(expected))
+	}
+	var out bytes.Buffer
+	out.Grow(len(expected))
+	// Outputs this size are not pooled, so the buffer is the one allocation
+	allocs := testing.AllocsPerRun(20, func() {
+		out.Reset()
+		_, _ = xprint.Fprintf(&out, format, args...)
+	})
+	if allocs > 1 {
+		t.Errorf("expected 1 allocation, got %v", allocs)
+	}
+}